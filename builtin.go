@@ -8,6 +8,8 @@ package gs
 import (
 	"constraints"
 	"errors"
+
+	"github.com/dairaga/gs/funcs"
 )
 
 var (
@@ -56,3 +58,30 @@ func T2[V1, V2 any](v1 V1, v2 V2) Tuple2[V1, V2] {
 		V2: v2,
 	}
 }
+
+// Tuple2Ordering returns a lexicographic Ordering of Tuple2 comparing V1 with given o1, and then V2 with given o2.
+func Tuple2Ordering[V1, V2 any](o1 funcs.Ordering[V1, V1], o2 funcs.Ordering[V2, V2]) funcs.Ordering[Tuple2[V1, V2], Tuple2[V1, V2]] {
+	return func(a, b Tuple2[V1, V2]) int {
+		if ret := o1(a.V1, b.V1); ret != 0 {
+			return ret
+		}
+		return o2(a.V2, b.V2)
+	}
+}
+
+// Tuple3 is tuple with size 3.
+type Tuple3[V1, V2, V3 any] struct {
+	_  struct{}
+	V1 V1
+	V2 V2
+	V3 V3
+}
+
+// T3 returns Tuple3.
+func T3[V1, V2, V3 any](v1 V1, v2 V2, v3 V3) Tuple3[V1, V2, V3] {
+	return Tuple3[V1, V2, V3]{
+		V1: v1,
+		V2: v2,
+		V3: v3,
+	}
+}
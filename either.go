@@ -6,6 +6,7 @@
 package gs
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/dairaga/gs/funcs"
@@ -18,7 +19,7 @@ type Either[L, R any] interface {
 	fmt.Stringer
 
 	// Fetch returns right value if this is a Right.
-	// err is original value if this is a Left with error type, or ErrLeft.
+	// err is original value if this is a Left with error type, or LeftError wrapping ErrLeft and Left value.
 	Fetch() (r R, err error)
 
 	// Get returns Right value if this is a Right, or panic.
@@ -63,7 +64,7 @@ type Either[L, R any] interface {
 	// Try converts to Try. Right converts to Success,
 	// and Left converts to Failure.
 	// Left value is reserved if Left type is error,
-	// or returns Failure with ErrLeft.
+	// or returns Failure with LeftError wrapping ErrLeft and Left value.
 	Try() Try[R]
 
 	// Option converts to Option. Right converts to Some,
@@ -87,8 +88,23 @@ func (e *either[L, R]) String() string {
 	return fmt.Sprintf(`Left(%v)`, e.left)
 }
 
+// MarshalJSON encodes a Left to {"left": value}, or encodes a Right to {"right": value}.
+// A Left with non-nil error is encoded to {"left": message of error} like a Failure of Try.
+func (e *either[L, R]) MarshalJSON() ([]byte, error) {
+	if e.ok {
+		return json.Marshal(map[string]R{"right": e.right})
+	}
+	if err, ok := any(e.left).(error); ok {
+		return json.Marshal(map[string]string{"left": err.Error()})
+	}
+	return json.Marshal(map[string]L{"left": e.left})
+}
+
 func (e *either[L, R]) Fetch() (R, error) {
-	return e.right, funcs.Cond(e.ok, nil, err(e.left))
+	if e.ok {
+		return e.right, nil
+	}
+	return e.right, err(e.left)
 }
 
 func (e *either[L, R]) Get() R {
@@ -157,12 +173,13 @@ func (e *either[L, R]) Try() Try[R] {
 	return failure[R](err(e.left))
 }
 
-func err(x interface{}) error {
-	switch v := x.(type) {
+// err returns given x if x is an error, or returns LeftError with x.
+func err[L any](x L) error {
+	switch v := interface{}(x).(type) {
 	case error:
 		return v
 	default:
-		return ErrLeft
+		return &LeftError[L]{Value: x}
 	}
 }
 
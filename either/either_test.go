@@ -6,11 +6,14 @@
 package either_test
 
 import (
+	"encoding/json"
+	"errors"
 	"strconv"
 	"testing"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/either"
+	"github.com/dairaga/gs/slices"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -90,3 +93,53 @@ func TestMap(t *testing.T) {
 	assertEither(t, gs.Right[int]("flower"), either.Map(gs.Right[int](12), f))
 	assertEither(t, gs.Left[int, string](12), either.Map(gs.Left[int, int](12), f))
 }
+
+func TestEJSON(t *testing.T) {
+	type st struct {
+		A either.E[string, int]           `json:"a"`
+		B either.E[string, slices.S[int]] `json:"b"`
+		C either.E[*int, gs.Nothing]      `json:"c"`
+	}
+
+	src := st{
+		A: either.Of(gs.Left[string, int]("a")),
+		B: either.Of(gs.Right[string](slices.From(1, 2))),
+		C: either.Of(gs.Left[*int, gs.Nothing](nil)),
+	}
+
+	data, err := json.Marshal(src)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"left":"a"},"b":{"right":[1,2]},"c":{"left":null}}`, string(data))
+
+	var dst st
+	assert.Nil(t, json.Unmarshal(data, &dst))
+	assertEither(t, gs.Left[string, int]("a"), dst.A.Either())
+	assertEither(t, gs.Right[string](slices.From(1, 2)), dst.B.Either())
+	assertEither(t, gs.Left[*int, gs.Nothing](nil), dst.C.Either())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":{}}`), &dst))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":{"left":"a","right":1}}`), &dst))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":{"right":"a"}}`), &dst))
+
+	type errst struct {
+		A either.E[error, int] `json:"a"`
+		B either.E[error, int] `json:"b"`
+		C either.E[error, int] `json:"c"`
+	}
+
+	esrc := errst{
+		A: either.Of(gs.Left[error, int](errors.New("oops"))),
+		B: either.Of(gs.Right[error](1)),
+	}
+	data, err = json.Marshal(esrc)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"left":"oops"},"b":{"right":1},"c":{"left":null}}`, string(data))
+
+	var edst errst
+	assert.Nil(t, json.Unmarshal(data, &edst))
+	assert.EqualError(t, edst.A.Left(), "oops")
+	assertEither(t, gs.Right[error](1), edst.B.Either())
+	assert.True(t, edst.C.IsLeft())
+	assert.Nil(t, edst.C.Left())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":{"left":1}}`), &edst))
+}
@@ -0,0 +1,152 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package either
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+)
+
+// E is a value type of Either, and can be a field of struct. Zero value of E is a Left with zero value.
+// E is encoded to JSON object {"left": value} if it is a Left, or {"right": value} if it is a Right.
+// If L is error, a Left is encoded to {"left": message of error}, and error decoded from JSON only keeps the message.
+type E[L, R any] struct {
+	_ struct{}
+	e gs.Either[L, R]
+}
+
+var _ gs.Either[int, int] = E[int, int]{}
+
+// Of returns a E from given Either e.
+func Of[L, R any](e gs.Either[L, R]) E[L, R] {
+	return E[L, R]{e: e}
+}
+
+// Either returns Either of this.
+func (e E[L, R]) Either() gs.Either[L, R] {
+	if e.e == nil {
+		var zero L
+		return gs.Left[L, R](zero)
+	}
+	return e.e
+}
+
+func (e E[L, R]) String() string {
+	return e.Either().String()
+}
+
+func (e E[L, R]) Fetch() (R, error) {
+	return e.Either().Fetch()
+}
+
+func (e E[L, R]) Get() R {
+	return e.Either().Get()
+}
+
+func (e E[L, R]) IsRight() bool {
+	return e.Either().IsRight()
+}
+
+func (e E[L, R]) Right() R {
+	return e.Either().Right()
+}
+
+func (e E[L, R]) IsLeft() bool {
+	return e.Either().IsLeft()
+}
+
+func (e E[L, R]) Left() L {
+	return e.Either().Left()
+}
+
+func (e E[L, R]) Exists(p funcs.Predict[R]) bool {
+	return e.Either().Exists(p)
+}
+
+func (e E[L, R]) Forall(p funcs.Predict[R]) bool {
+	return e.Either().Forall(p)
+}
+
+func (e E[L, R]) Foreach(op func(R)) {
+	e.Either().Foreach(op)
+}
+
+func (e E[L, R]) FilterOrElse(p funcs.Predict[R], z L) gs.Either[L, R] {
+	return e.Either().FilterOrElse(p, z)
+}
+
+func (e E[L, R]) GetOrElse(z R) R {
+	return e.Either().GetOrElse(z)
+}
+
+func (e E[L, R]) OrElse(z gs.Either[L, R]) gs.Either[L, R] {
+	return e.Either().OrElse(z)
+}
+
+func (e E[L, R]) Swap() gs.Either[R, L] {
+	return e.Either().Swap()
+}
+
+func (e E[L, R]) Try() gs.Try[R] {
+	return e.Either().Try()
+}
+
+func (e E[L, R]) Option() gs.Option[R] {
+	return e.Either().Option()
+}
+
+func (e E[L, R]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Either())
+}
+
+func (e *E[L, R]) UnmarshalJSON(data []byte) error {
+	var tagged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return err
+	}
+
+	left, isLeft := tagged["left"]
+	right, isRight := tagged["right"]
+
+	switch {
+	case len(tagged) == 1 && isLeft:
+		var v L
+		if err := unmarshalLeft(left, &v); err != nil {
+			return err
+		}
+		e.e = gs.Left[L, R](v)
+	case len(tagged) == 1 && isRight:
+		var v R
+		if err := json.Unmarshal(right, &v); err != nil {
+			return err
+		}
+		e.e = gs.Right[L](v)
+	default:
+		return fmt.Errorf(`either: JSON must contain exactly one of "left" and "right": %s`, data)
+	}
+	return nil
+}
+
+// unmarshalLeft decodes given data to v. v is decoded from message of error if L is error.
+func unmarshalLeft[L any](data []byte, v *L) error {
+	p, ok := any(v).(*error)
+	if !ok {
+		return json.Unmarshal(data, v)
+	}
+
+	var msg *string
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	if msg != nil {
+		*p = errors.New(*msg)
+	}
+	return nil
+}
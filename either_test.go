@@ -6,6 +6,7 @@
 package gs_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -60,11 +61,11 @@ func TestLeft(t *testing.T) {
 
 	v, err := e.Fetch()
 	assert.Equal(t, 0, v)
-	assert.True(t, errors.Is(gs.ErrLeft, err))
+	assert.True(t, errors.Is(err, gs.ErrLeft))
 
 	try := e.Try()
 	assert.True(t, try.IsFailure())
-	assert.True(t, errors.Is(gs.ErrLeft, try.Failed()))
+	assert.True(t, errors.Is(try.Failed(), gs.ErrLeft))
 
 	opt := e.Option()
 	assert.True(t, opt.IsEmpty())
@@ -169,3 +170,32 @@ func TestEitherOrElse(t *testing.T) {
 func TestEitherSwap(t *testing.T) {
 	assertEither(t, gs.Right[int]("left"), gs.Left[string, int]("left").Swap())
 }
+
+func TestEitherMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(gs.Right[string](1))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"right":1}`, string(data))
+
+	data, err = json.Marshal(gs.Left[string, int]("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"left":"a"}`, string(data))
+}
+
+func TestLeftError(t *testing.T) {
+	type payload struct {
+		Code int
+	}
+
+	e := gs.Left[payload, int](payload{Code: 1})
+	_, err := e.Fetch()
+	assert.True(t, errors.Is(err, gs.ErrLeft))
+
+	var lerr *gs.LeftError[payload]
+	assert.True(t, errors.As(e.Try().Failed(), &lerr))
+	assert.Equal(t, payload{Code: 1}, lerr.Value)
+	assert.Equal(t, "Left({1})", lerr.Error())
+
+	src := errors.New("left")
+	_, err = gs.Left[error, int](src).Fetch()
+	assert.Equal(t, src, err)
+}
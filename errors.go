@@ -0,0 +1,50 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package gs
+
+import "fmt"
+
+// UnsatisfiedError represents value is rejected by a prediction. It wraps ErrUnsatisfied.
+type UnsatisfiedError[T any] struct {
+	Value T
+}
+
+func (e *UnsatisfiedError[T]) Error() string {
+	return fmt.Sprintf(`%s: %v`, ErrUnsatisfied.Error(), e.Value)
+}
+
+func (e *UnsatisfiedError[T]) Unwrap() error {
+	return ErrUnsatisfied
+}
+
+// LeftError represents value from Left which is not an error. It wraps ErrLeft.
+type LeftError[L any] struct {
+	Value L
+}
+
+func (e *LeftError[L]) Error() string {
+	return fmt.Sprintf(`%s(%v)`, ErrLeft.Error(), e.Value)
+}
+
+func (e *LeftError[L]) Unwrap() error {
+	return ErrLeft
+}
+
+// PanicError represents a recovered panic with its value and stack of goroutine.
+// It wraps recovered value if the value is an error.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf(`panic: %v`, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
@@ -50,3 +50,28 @@ func EqualTo[T, U any](v U, eq Equal[T, U]) func(T) bool {
 		return eq(t, v)
 	}
 }
+
+// By returns an Ordering comparing results of given function op.
+func By[T any, R constraints.Ordered](op Orderize[T, R]) Ordering[T, T] {
+	return func(a, b T) int {
+		return Order(op(a), op(b))
+	}
+}
+
+// Reverse returns an Ordering in reverse order of given o.
+func Reverse[T, U any](o Ordering[T, U]) Ordering[T, U] {
+	return func(a T, b U) int {
+		return -o(a, b)
+	}
+}
+
+// ThenBy returns an Ordering comparing with given o first, and then with next orderings in turn if elements are equal.
+func ThenBy[T, U any](o Ordering[T, U], next ...Ordering[T, U]) Ordering[T, U] {
+	return func(a T, b U) int {
+		ret := o(a, b)
+		for i := 0; ret == 0 && i < len(next); i++ {
+			ret = next[i](a, b)
+		}
+		return ret
+	}
+}
@@ -38,11 +38,11 @@ type Future[T any] interface {
 	OnCompleted(p func(Try[T]))
 
 	// Filter returns a new Future to wait this and apply result to given function p.
-	// Returned Future contains result from this if result is Failure or satisfies given function p, or contains Failure with ErrUnsatisfied.
+	// Returned Future contains result from this if result is Failure or satisfies given function p, or contains Failure with UnsatisfiedError.
 	Filter(ctx context.Context, p funcs.Predict[T]) Future[T]
 
 	// FilterNot returns a new Future to wait this and apply result to given function p.
-	// Returned Future contains result from this if result is Failure or dose not satisfies given function p, or contains Failure with ErrUnsatisfied.
+	// Returned Future contains result from this if result is Failure or dose not satisfies given function p, or contains Failure with UnsatisfiedError.
 	FilterNot(context.Context, funcs.Predict[T]) Future[T]
 
 	// Result waits result at most given time t.
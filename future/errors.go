@@ -0,0 +1,42 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package future
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors is a list of errors from failed futures.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is returns true if any error in e matches given target.
+func (e Errors) Is(target error) bool {
+	for i := range e {
+		if errors.Is(e[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in e that matches given target, and sets target to that error.
+func (e Errors) As(target interface{}) bool {
+	for i := range e {
+		if errors.As(e[i], target) {
+			return true
+		}
+	}
+	return false
+}
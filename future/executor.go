@@ -0,0 +1,186 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package future
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/try"
+)
+
+// ErrRejected represents a task is rejected by an Executor.
+var ErrRejected = errors.New("rejected")
+
+// Executor runs tasks of futures.
+type Executor interface {
+	// Execute runs given task, and returns ErrRejected if the task is rejected.
+	Execute(task func()) error
+}
+
+type executor func(func()) error
+
+func (e executor) Execute(task func()) error {
+	return e(task)
+}
+
+var (
+	// Unbounded runs each task in a new goroutine.
+	Unbounded Executor = executor(func(task func()) error {
+		go task()
+		return nil
+	})
+
+	// Immediate runs each task in the caller goroutine. It is useful in tests.
+	Immediate Executor = executor(func(task func()) error {
+		task()
+		return nil
+	})
+)
+
+// Policy decides what to do when the queue of a Pool is full.
+type Policy int
+
+const (
+	// Block blocks the caller until the queue has room or the Pool is closed.
+	// A task must not submit tasks to its own Pool with Block, because it may wait for a worker running itself. Use CallerRuns instead.
+	Block Policy = iota
+
+	// Reject rejects the task with ErrRejected.
+	Reject
+
+	// CallerRuns runs the task in the caller goroutine.
+	CallerRuns
+)
+
+// Pool is a bounded Executor running tasks with a fixed number of workers.
+// A panic in a task run by a worker is recovered and passed to the handler set by OnPanic, and does not stop the worker.
+type Pool struct {
+	_       struct{}
+	policy  Policy
+	mu      sync.RWMutex
+	closed  bool
+	handler func(error)
+	done    chan struct{}
+	senders sync.WaitGroup
+	tasks   chan func()
+}
+
+var _ Executor = &Pool{}
+
+// NewPool returns a Pool with given numbers of workers, size of queue and policy when the queue is full.
+func NewPool(workers, queue int, policy Policy) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	if queue < 0 {
+		queue = 0
+	}
+
+	p := &Pool{
+		policy: policy,
+		done:   make(chan struct{}),
+		tasks:  make(chan func(), queue),
+	}
+
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	for task := range p.tasks {
+		p.run(task)
+	}
+}
+
+// run runs given task, and passes its panic to the handler of p.
+func (p *Pool) run(task func()) {
+	err := try.Catch(func() gs.Nothing {
+		task()
+		return gs.N()
+	}).Failed()
+	if err == nil {
+		return
+	}
+
+	p.mu.RLock()
+	h := p.handler
+	p.mu.RUnlock()
+	if h != nil {
+		h(err)
+	}
+}
+
+// OnPanic sets given function h to handle panics of tasks run by workers, and returns p.
+// h receives a *gs.PanicError. Panics are discarded if h is nil, which is the default.
+func (p *Pool) OnPanic(h func(error)) *Pool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handler = h
+	return p
+}
+
+// offer queues given task, and returns false if the task is not queued.
+func (p *Pool) offer(task func()) (bool, error) {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return false, ErrRejected
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+	defer p.senders.Done()
+
+	select {
+	case p.tasks <- task:
+		return true, nil
+	default:
+	}
+
+	switch p.policy {
+	case Reject:
+		return false, ErrRejected
+	case CallerRuns:
+		return false, nil
+	default:
+		select {
+		case p.tasks <- task:
+			return true, nil
+		case <-p.done:
+			return false, ErrRejected
+		}
+	}
+}
+
+// Execute queues given task, and applies policy of this if the queue is full.
+// It returns ErrRejected if this is closed, or is closed while blocking.
+// A panic of the task is passed to the handler set by OnPanic if a worker runs it, or propagates to the caller with CallerRuns.
+func (p *Pool) Execute(task func()) error {
+	queued, err := p.offer(task)
+	if err == nil && !queued {
+		task()
+	}
+	return err
+}
+
+// Close stops accepting new tasks, and rejects callers blocking on the full queue. Queued tasks are still run.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	p.senders.Wait()
+	close(p.tasks)
+}
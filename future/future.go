@@ -7,44 +7,75 @@ package future
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"reflect"
+	"sync"
 	"time"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/slices"
 	"github.com/dairaga/gs/try"
 )
 
+// ErrCompleted represents a Future has been completed already.
+var ErrCompleted = errors.New("completed")
+
 type F[T any] struct {
 	_         struct{}
 	ctx       context.Context
 	cancel    context.CancelFunc
+	mu        sync.RWMutex
 	completed bool
 	result    gs.Try[T]
 }
 
 var _ gs.Future[int] = &F[int]{}
 
-func (f *F[T]) assign(x gs.Try[T]) *F[T] {
+// complete publishes given result x exactly once, and then releases all waiters.
+// It returns ErrCompleted if f has been completed, or error from context if f has been canceled.
+func (f *F[T]) complete(x gs.Try[T]) error {
+	f.mu.Lock()
+	if f.completed {
+		f.mu.Unlock()
+		return ErrCompleted
+	}
+	if err := f.ctx.Err(); err != nil {
+		f.mu.Unlock()
+		return err
+	}
 	f.result = x
 	f.completed = true
+	f.mu.Unlock()
+
 	f.cancel()
+	return nil
+}
+
+// assign completes f with given result x, and panics if f has been completed.
+func (f *F[T]) assign(x gs.Try[T]) *F[T] {
+	if err := f.complete(x); errors.Is(err, ErrCompleted) {
+		panic(err)
+	}
 	return f
 }
 
 func (f *F[T]) String() string {
-	if f.completed {
-		return fmt.Sprintf(`Completed(%v)`, f.result)
+	if result, completed := f.Get(); completed {
+		return fmt.Sprintf(`Completed(%v)`, result)
 	}
 	return fmt.Sprintf(`Future(?)`)
 }
 
 func (f *F[T]) Completed() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 	return f.completed
 }
 
 func (f *F[T]) Get() (gs.Try[T], bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 	return f.result, f.completed
 }
 
@@ -55,8 +86,8 @@ func (f *F[T]) Done() <-chan struct{} {
 func (f *F[T]) OnCompleted(op func(gs.Try[T])) {
 	go func() {
 		<-f.Done()
-		if f.completed {
-			op(f.result)
+		if result, completed := f.Get(); completed {
+			op(result)
 		}
 	}()
 }
@@ -64,8 +95,8 @@ func (f *F[T]) OnCompleted(op func(gs.Try[T])) {
 func (f *F[T]) OnSuccess(op func(T)) {
 	go func() {
 		<-f.Done()
-		if f.completed && f.result.IsSuccess() {
-			op(f.result.Get())
+		if result, completed := f.Get(); completed && result.IsSuccess() {
+			op(result.Get())
 		}
 	}()
 }
@@ -73,18 +104,16 @@ func (f *F[T]) OnSuccess(op func(T)) {
 func (f *F[T]) OnError(op func(error)) {
 	go func() {
 		<-f.Done()
-		if f.completed && f.result.IsFailure() {
-			op(f.result.Failed())
+		if result, completed := f.Get(); completed && result.IsFailure() {
+			op(result.Failed())
 		}
 	}()
 }
 
 func (f *F[T]) Wait() gs.Try[T] {
-	if f.completed {
-		return f.result
-	}
 	<-f.Done()
-	return f.result
+	result, _ := f.Get()
+	return result
 }
 
 func (f *F[T]) Result(ctx context.Context, atMost time.Duration) gs.Try[T] {
@@ -93,8 +122,8 @@ func (f *F[T]) Result(ctx context.Context, atMost time.Duration) gs.Try[T] {
 
 	select {
 	case <-f.Done():
-		if f.completed {
-			return f.result
+		if result, completed := f.Get(); completed {
+			return result
 		}
 		return gs.Failure[T](f.ctx.Err())
 	case <-wait.Done():
@@ -104,13 +133,7 @@ func (f *F[T]) Result(ctx context.Context, atMost time.Duration) gs.Try[T] {
 
 func (f *F[T]) Filter(ctx context.Context, p funcs.Predict[T]) gs.Future[T] {
 	return TransformWith[T](ctx, f, func(x gs.Try[T]) gs.Future[T] {
-		return promise[T](ctx).assign(
-			funcs.Cond(
-				x.IsFailure() || p(x.Success()),
-				x,
-				gs.Failure[T](gs.ErrUnsatisfied),
-			),
-		)
+		return promise[T](ctx).assign(x.Filter(p))
 	})
 }
 
@@ -133,37 +156,73 @@ func promise[T any](parent context.Context) *F[T] {
 	return ret
 }
 
-// Run returns a Future waitng for the result from given function op.
-func Run[T any](parent context.Context, op func() T) gs.Future[T] {
-	ret := promise[T](parent)
+// abort cancels given future f if f is not completed and built in this package.
+func abort[T any](f gs.Future[T]) {
+	if x, ok := f.(*F[T]); ok {
+		x.cancel()
+	}
+}
 
-	go func(op func() T, f *F[T]) {
-		defer func() {
-			if r := recover(); r != nil {
-				switch v := r.(type) {
-				case error:
-					f.result = gs.Failure[T](v)
-				default:
-					f.result = gs.Failure[T](fmt.Errorf(`%v`, v))
-				}
-			}
-			f.completed = true
-			f.cancel()
-		}()
+// execute runs given function op completing f on given executor exec.
+// f fails with PanicError if op panics, or with the error from exec if exec rejects op.
+func (f *F[T]) execute(exec Executor, op func()) *F[T] {
+	task := func() {
+		if f.ctx.Err() != nil {
+			return
+		}
 
-		f.result = gs.Success(op())
-	}(op, ret)
+		result := try.Catch(func() gs.Nothing {
+			op()
+			return gs.N()
+		})
+		if result.IsFailure() {
+			f.complete(gs.Failure[T](result.Failed()))
+		}
+	}
 
-	return ret
+	if err := exec.Execute(task); err != nil {
+		f.complete(gs.Failure[T](err))
+	}
+	return f
+}
+
+// follow completes f with the result of given future g.
+func (f *F[T]) follow(g gs.Future[T]) {
+	defer f.cancel()
+
+	select {
+	case <-g.Done():
+		if result, completed := g.Get(); completed {
+			f.complete(result)
+		}
+	case <-f.Done():
+	}
+}
+
+// Run returns a Future waitng for the result from given function op.
+func Run[T any](parent context.Context, op func() T) gs.Future[T] {
+	return RunOn(parent, Unbounded, op)
+}
+
+// RunOn returns a Future waitng for the result from given function op running on given executor exec.
+func RunOn[T any](parent context.Context, exec Executor, op func() T) gs.Future[T] {
+	ret := promise[T](parent)
+	return ret.execute(exec, func() {
+		ret.complete(try.Catch(op))
+	})
 }
 
 // Try returns a Future waitng for the result from given function op.
 func Try[T any](parent context.Context, op func() (T, error)) gs.Future[T] {
+	return TryOn(parent, Unbounded, op)
+}
+
+// TryOn returns a Future waitng for the result from given function op running on given executor exec.
+func TryOn[T any](parent context.Context, exec Executor, op func() (T, error)) gs.Future[T] {
 	ret := promise[T](parent)
-	go func(f *F[T]) {
-		f.assign(try.From(op()))
-	}(ret)
-	return ret
+	return ret.execute(exec, func() {
+		ret.complete(try.CatchErr(op))
+	})
 }
 
 // -----------------------------------------------------------------------------
@@ -172,58 +231,64 @@ func Try[T any](parent context.Context, op func() (T, error)) gs.Future[T] {
 
 // Transform returns a Future waiting for the result applied by given function to result of given future f.
 func Transform[T, U any](ctx context.Context, f gs.Future[T], op func(gs.Try[T]) gs.Try[U]) gs.Future[U] {
+	return TransformOn(ctx, Unbounded, f, op)
+}
+
+// TransformOn returns a Future waiting for the result applied by given function running on given executor exec to result of given future f.
+func TransformOn[T, U any](ctx context.Context, exec Executor, f gs.Future[T], op func(gs.Try[T]) gs.Try[U]) gs.Future[U] {
 	ret := promise[U](ctx)
 
-	go func(ctx context.Context, f gs.Future[T], op func(gs.Try[T]) gs.Try[U], ret *F[U]) {
+	go func(f gs.Future[T], op func(gs.Try[T]) gs.Try[U], ret *F[U]) {
 		select {
 		case <-f.Done():
-			result, completed := f.Get()
-			if completed {
-				ret.result = op(result)
-				ret.completed = true
+			if result, completed := f.Get(); completed {
+				ret.execute(exec, func() {
+					ret.complete(op(result))
+				})
+				return
 			}
 		case <-ret.Done():
 		}
 		ret.cancel()
-	}(ctx, f, op, ret)
+	}(f, op, ret)
 
 	return ret
 }
 
 // TransformWith returns a new Future to wait another Future made by applying the given function op to the result of future f.
 func TransformWith[T, U any](ctx context.Context, f gs.Future[T], op func(gs.Try[T]) gs.Future[U]) gs.Future[U] {
+	return TransformWithOn(ctx, Unbounded, f, op)
+}
+
+// TransformWithOn returns a new Future to wait another Future made by applying the given function op running on given executor exec to the result of future f.
+func TransformWithOn[T, U any](ctx context.Context, exec Executor, f gs.Future[T], op func(gs.Try[T]) gs.Future[U]) gs.Future[U] {
 	ret := promise[U](ctx)
 
-	go func(ctx context.Context, f gs.Future[T], op func(gs.Try[T]) gs.Future[U], ret *F[U]) {
+	go func(f gs.Future[T], op func(gs.Try[T]) gs.Future[U], ret *F[U]) {
 		select {
 		case <-f.Done():
-			fresult, fcompleted := f.Get()
-			if fcompleted {
-				g := op(fresult)
-				go func() {
-					select {
-					case <-g.Done():
-						gresult, gcompleted := g.Get()
-						if gcompleted {
-							ret.result = gresult
-							ret.completed = true
-						}
-					case <-ret.Done():
-					}
-					ret.cancel()
-				}()
+			if result, completed := f.Get(); completed {
+				ret.execute(exec, func() {
+					go ret.follow(op(result))
+				})
+				return
 			}
 		case <-ret.Done():
-			ret.cancel()
 		}
-	}(ctx, f, op, ret)
+		ret.cancel()
+	}(f, op, ret)
 
 	return ret
 }
 
 // FlatMap returns a new Future by applying given function op to the successful result of future f, and returns the result of the function as the new future.
 func FlatMap[T, U any](ctx context.Context, f gs.Future[T], op func(T) gs.Future[U]) gs.Future[U] {
-	return TransformWith(ctx, f, func(x gs.Try[T]) gs.Future[U] {
+	return FlatMapOn(ctx, Unbounded, f, op)
+}
+
+// FlatMapOn returns a new Future by applying given function op running on given executor exec to the successful result of future f, and returns the result of the function as the new future.
+func FlatMapOn[T, U any](ctx context.Context, exec Executor, f gs.Future[T], op func(T) gs.Future[U]) gs.Future[U] {
+	return TransformWithOn(ctx, exec, f, func(x gs.Try[T]) gs.Future[U] {
 		if x.IsSuccess() {
 			return op(x.Success())
 		}
@@ -233,7 +298,12 @@ func FlatMap[T, U any](ctx context.Context, f gs.Future[T], op func(T) gs.Future
 
 // Map returns a new Future by applying given function op to the successful result of future f.
 func Map[T, U any](ctx context.Context, f gs.Future[T], op func(T) U) gs.Future[U] {
-	return Transform(ctx, f, func(x gs.Try[T]) gs.Try[U] {
+	return MapOn(ctx, Unbounded, f, op)
+}
+
+// MapOn returns a new Future by applying given function op running on given executor exec to the successful result of future f.
+func MapOn[T, U any](ctx context.Context, exec Executor, f gs.Future[T], op func(T) U) gs.Future[U] {
+	return TransformOn(ctx, exec, f, func(x gs.Try[T]) gs.Try[U] {
 		return try.Map(x, op)
 	})
 }
@@ -259,49 +329,25 @@ func Zip[T, U any](ctx context.Context, f gs.Future[T], g gs.Future[U]) gs.Futur
 	go func(f gs.Future[T], g gs.Future[U], ret *F[gs.Tuple2[gs.Try[T], gs.Try[U]]]) {
 		defer ret.cancel()
 
-		cases := []reflect.SelectCase{
-			{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(ret.Done()),
-			},
-			{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(f.Done()),
-			},
-			{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(g.Done()),
-			},
+		select {
+		case <-f.Done():
+		case <-ret.Done():
+			return
 		}
 
-		var fresult gs.Try[T]
-		var gresult gs.Try[U]
-		var fcompleted, gcompleted bool
-
-		for !f.Completed() || !g.Completed() {
-			chosen, _, _ := reflect.Select(cases)
-			if chosen == 0 {
-				break
-			}
-
-			switch chosen {
-			case 1:
-				cases = append(cases[0:1], cases[2:]...)
-				if fresult == nil {
-					fresult, fcompleted = f.Get()
-				} else {
-					gresult, gcompleted = g.Get()
-				}
+		fresult, fcompleted := f.Get()
+		if !fcompleted {
+			return
+		}
 
-			case 2:
-				cases = cases[0:2]
-				gresult, gcompleted = g.Get()
-			}
+		select {
+		case <-g.Done():
+		case <-ret.Done():
+			return
 		}
 
-		ret.completed = fcompleted && gcompleted
-		if ret.completed {
-			ret.result = gs.Success(gs.T2(fresult, gresult))
+		if gresult, gcompleted := g.Get(); gcompleted {
+			ret.complete(gs.Success(gs.T2(fresult, gresult)))
 		}
 	}(f, g, ret)
 
@@ -316,3 +362,135 @@ func ZipWith[T, U, R any](ctx context.Context, f gs.Future[T], g gs.Future[U], o
 		})
 	})
 }
+
+// resultOf returns the result of given done future f like f.Get, but returns a Failure with ErrEmpty if the result is nil.
+// A Future not built in this package may return nil after it is canceled.
+func resultOf[T any](f gs.Future[T]) (gs.Try[T], bool) {
+	result, ok := f.Get()
+	if result == nil {
+		return gs.Failure[T](gs.ErrEmpty), ok
+	}
+	return result, ok
+}
+
+// watch returns a channel receiving indexes of given futures fs when they are done, until given done is closed.
+func watch[T any](done <-chan struct{}, fs []gs.Future[T]) <-chan int {
+	ch := make(chan int, len(fs))
+	for i := range fs {
+		go func(f gs.Future[T], i int) {
+			select {
+			case <-f.Done():
+				ch <- i
+			case <-done:
+			}
+		}(fs[i], i)
+	}
+	return ch
+}
+
+// sequence returns a Future waiting for results of all given futures fs and building its result with given function op.
+// The returned Future fails with the first Failure if failFast is true.
+// Futures in fs which are not completed are canceled when the returned Future is done.
+func sequence[T, R any](ctx context.Context, fs slices.S[gs.Future[T]], failFast bool, op func(slices.S[gs.Try[T]]) R) gs.Future[R] {
+	ret := promise[R](ctx)
+
+	go func(fs slices.S[gs.Future[T]], ret *F[R]) {
+		defer func() {
+			ret.cancel()
+			for i := range fs {
+				abort(fs[i])
+			}
+		}()
+
+		ch := watch(ret.Done(), fs)
+		results := make(slices.S[gs.Try[T]], len(fs))
+		for n := 0; n < len(fs); n++ {
+			select {
+			case i := <-ch:
+				results[i], _ = resultOf(fs[i])
+				if failFast && results[i].IsFailure() {
+					ret.complete(gs.Failure[R](results[i].Failed()))
+					return
+				}
+			case <-ret.Done():
+				return
+			}
+		}
+		ret.complete(gs.Success(op(results)))
+	}(fs, ret)
+
+	return ret
+}
+
+// Sequence returns a Future waiting for successful results of all given futures fs in order.
+// The returned Future fails fast with the first Failure, and cancels the rest of fs.
+func Sequence[T any](ctx context.Context, fs slices.S[gs.Future[T]]) gs.Future[slices.S[T]] {
+	return sequence(ctx, fs, true, func(results slices.S[gs.Try[T]]) slices.S[T] {
+		return slices.Map(results, gs.Try[T].Get)
+	})
+}
+
+// SequenceAll returns a Future waiting for results of all given futures fs in order, even some of them are failed.
+func SequenceAll[T any](ctx context.Context, fs slices.S[gs.Future[T]]) gs.Future[slices.S[gs.Try[T]]] {
+	return sequence(ctx, fs, false, funcs.Self[slices.S[gs.Try[T]]])
+}
+
+// Traverse applies given function op to all elements of given s, and returns a Future waiting for successful results in order.
+// The returned Future fails fast with the first Failure, and cancels the rest of futures.
+func Traverse[A, B any](ctx context.Context, s slices.S[A], op func(A) gs.Future[B]) gs.Future[slices.S[B]] {
+	return Sequence(ctx, slices.Map(s, op))
+}
+
+// TraverseAll applies given function op to all elements of given s, and returns a Future waiting for all results in order.
+func TraverseAll[A, B any](ctx context.Context, s slices.S[A], op func(A) gs.Future[B]) gs.Future[slices.S[gs.Try[B]]] {
+	return SequenceAll(ctx, slices.Map(s, op))
+}
+
+// first returns a Future holding the result of the first completed future in given fs.
+// Failures are skipped if succ is true.
+// Futures in fs which are not completed are canceled when the returned Future is done.
+func first[T any](ctx context.Context, fs []gs.Future[T], succ bool) gs.Future[T] {
+	ret := promise[T](ctx)
+	if len(fs) <= 0 {
+		return ret.assign(gs.Failure[T](gs.ErrEmpty))
+	}
+
+	go func(fs []gs.Future[T], ret *F[T]) {
+		defer func() {
+			ret.cancel()
+			for i := range fs {
+				abort(fs[i])
+			}
+		}()
+
+		ch := watch(ret.Done(), fs)
+		errs := make(Errors, 0, len(fs))
+		for n := 0; n < len(fs); n++ {
+			select {
+			case i := <-ch:
+				result, completed := resultOf(fs[i])
+				if completed && (!succ || result.IsSuccess()) {
+					ret.complete(result)
+					return
+				}
+				errs = append(errs, result.Failed())
+			case <-ret.Done():
+				return
+			}
+		}
+		ret.complete(gs.Failure[T](errs))
+	}(fs, ret)
+
+	return ret
+}
+
+// FirstCompletedOf returns a Future holding the result of the first completed future in given fs, and cancels the others.
+func FirstCompletedOf[T any](ctx context.Context, fs ...gs.Future[T]) gs.Future[T] {
+	return first(ctx, fs, false)
+}
+
+// FirstSuccessOf returns a Future holding the first successful result of given fs, and cancels the others.
+// The returned Future fails with Errors containing all errors if all of fs are failed.
+func FirstSuccessOf[T any](ctx context.Context, fs ...gs.Future[T]) gs.Future[T] {
+	return first(ctx, fs, true)
+}
@@ -8,12 +8,17 @@ package future_test
 import (
 	"context"
 	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
 	"github.com/dairaga/gs/future"
+	"github.com/dairaga/gs/slices"
+	"github.com/dairaga/gs/try"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +32,7 @@ func assertResult[T any](t *testing.T, a, b gs.Try[T]) {
 	}
 
 	if a.IsFailure() {
-		assert.True(t, errors.Is(a.Failed(), b.Failed()))
+		assert.True(t, errors.Is(b.Failed(), a.Failed()))
 	}
 }
 
@@ -73,8 +78,18 @@ func TestRun(t *testing.T) {
 	assert.True(t, completed)
 
 	assert.True(t, result.IsFailure())
-	assert.True(t, errors.Is(gs.ErrEmpty, result.Failed()))
+	assert.True(t, errors.Is(result.Failed(), gs.ErrEmpty))
+
+	var perr *gs.PanicError
+	assert.True(t, errors.As(result.Failed(), &perr))
+	assert.Equal(t, gs.ErrEmpty, perr.Value)
+	assert.NotEmpty(t, perr.Stack)
 
+	f = future.Run(context.Background(), func() int { panic(nil) })
+	result = f.Wait()
+	assert.NotNil(t, result)
+	assert.True(t, result.IsFailure())
+	assert.True(t, errors.As(result.Failed(), &perr))
 }
 
 func TestTry(t *testing.T) {
@@ -114,54 +129,54 @@ func TestCallback(t *testing.T) {
 		return 1, nil
 	}
 
-	check := 0
+	var check int32
 	f := future.Try(context.Background(), try)
 	f.OnCompleted(func(x gs.Try[int]) {
-		check++
+		atomic.AddInt32(&check, 1)
 		assert.True(t, x.IsSuccess())
 		assert.Equal(t, 1, x.Success())
 		ch <- struct{}{}
 	})
 	f.OnSuccess(func(x int) {
-		check++
+		atomic.AddInt32(&check, 1)
 		assert.Equal(t, 1, x)
 		ch <- struct{}{}
 	})
 	f.OnError(func(err error) {
-		check++
+		atomic.AddInt32(&check, 1)
 		ch <- struct{}{}
 	})
 	f.Wait()
 	<-ch
 	<-ch
-	assert.Equal(t, 2, check)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&check))
 
 	try = func() (int, error) {
 		return 0, gs.ErrEmpty
 	}
 
-	check = 0
+	atomic.StoreInt32(&check, 0)
 	f = future.Try(context.Background(), try)
 	f.OnCompleted(func(x gs.Try[int]) {
-		check++
+		atomic.AddInt32(&check, 1)
 		assert.True(t, x.IsFailure())
 		assert.True(t, errors.Is(gs.ErrEmpty, x.Failed()))
 		ch <- struct{}{}
 	})
 	f.OnSuccess(func(x int) {
-		check++
+		atomic.AddInt32(&check, 1)
 		ch <- struct{}{}
 	})
 
 	f.OnError(func(err error) {
-		check++
+		atomic.AddInt32(&check, 1)
 		assert.True(t, errors.Is(gs.ErrEmpty, err))
 		ch <- struct{}{}
 	})
 	f.Wait()
 	<-ch
 	<-ch
-	assert.Equal(t, 2, check)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&check))
 
 }
 
@@ -219,8 +234,11 @@ func TestFilter(t *testing.T) {
 	assert.Equal(t, 5, result.Get())
 
 	result = h.Result(context.Background(), 5*time.Second)
-	assert.True(t, errors.Is(gs.ErrUnsatisfied, result.Failed()))
+	assert.True(t, errors.Is(result.Failed(), gs.ErrUnsatisfied))
 
+	var uerr *gs.UnsatisfiedError[int]
+	assert.True(t, errors.As(result.Failed(), &uerr))
+	assert.Equal(t, 5, uerr.Value)
 }
 
 func TestFilterNot(t *testing.T) {
@@ -237,7 +255,7 @@ func TestFilterNot(t *testing.T) {
 	h := f.FilterNot(context.Background(), p2)
 
 	result := g.Result(context.Background(), 5*time.Second)
-	assert.True(t, errors.Is(gs.ErrUnsatisfied, result.Failed()))
+	assert.True(t, errors.Is(result.Failed(), gs.ErrUnsatisfied))
 
 	result = h.Result(context.Background(), 5*time.Second)
 	assert.Equal(t, 5, result.Get())
@@ -352,3 +370,480 @@ func TestZipWith(t *testing.T) {
 
 	assertResult(t, gs.Success("ok"), result)
 }
+
+func TestRunRace(t *testing.T) {
+	const n = 100
+
+	ctx := context.Background()
+	fs := make([]gs.Future[int], n)
+	for i := range fs {
+		fs[i] = future.Run(ctx, funcs.Id(i))
+	}
+
+	var wg sync.WaitGroup
+	for i := range fs {
+		f := fs[i]
+		wg.Add(3)
+		f.OnCompleted(func(gs.Try[int]) { wg.Done() })
+		f.OnSuccess(func(int) { wg.Done() })
+		go func() {
+			defer wg.Done()
+			for !f.Completed() {
+				f.Get()
+				_ = f.String()
+			}
+		}()
+	}
+
+	for i := range fs {
+		assert.Equal(t, i, fs[i].Wait().Get())
+		assert.Equal(t, i, fs[i].Result(ctx, 5*time.Second).Get())
+	}
+	wg.Wait()
+}
+
+func TestTransformRace(t *testing.T) {
+	const n = 100
+
+	ctx := context.Background()
+	f := future.Run(ctx, funcs.Id(1))
+
+	var wg sync.WaitGroup
+	results := make([]gs.Future[int], n)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = future.Map(
+				ctx,
+				future.FlatMap(ctx, f, func(v int) gs.Future[int] {
+					return future.Run(ctx, funcs.Id(v+i))
+				}),
+				func(v int) int { return v * 2 },
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range results {
+		assert.Equal(t, (1+i)*2, results[i].Wait().Get())
+	}
+}
+
+func TestZipRace(t *testing.T) {
+	const n = 100
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f := future.Run(ctx, funcs.Id(i))
+			g := future.Try(ctx, func() (string, error) { return strconv.Itoa(i), nil })
+			h := future.Zip(ctx, f, g)
+			h.OnSuccess(func(x gs.Tuple2[gs.Try[int], gs.Try[string]]) {
+				assert.Equal(t, i, x.V1.Get())
+			})
+			result := h.Wait()
+			assert.Equal(t, i, result.Get().V1.Get())
+			assert.Equal(t, strconv.Itoa(i), result.Get().V2.Get())
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCanceledRace(t *testing.T) {
+	const n = 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithCancel(context.Background())
+			f := future.Run(ctx, funcs.Id(1))
+			go cancel()
+			<-f.Done()
+			result, completed := f.Get()
+			if completed {
+				assert.Equal(t, 1, result.Get())
+			} else {
+				assertResult(t, future.Failure[int](), result)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPromise(t *testing.T) {
+	p := future.NewPromise[int](context.Background())
+	f := p.Future()
+	assert.False(t, p.IsCompleted())
+	assert.False(t, f.Completed())
+
+	go func() {
+		assert.Nil(t, p.Success(1))
+	}()
+	assertResult(t, gs.Success(1), f.Wait())
+	assert.True(t, p.IsCompleted())
+
+	assert.True(t, errors.Is(p.Success(2), future.ErrCompleted))
+	assert.True(t, errors.Is(p.Failure(gs.ErrEmpty), future.ErrCompleted))
+	assert.False(t, p.TryComplete(gs.Success(3)))
+	assertResult(t, gs.Success(1), f.Wait())
+
+	p = future.NewPromise[int](context.Background())
+	assert.True(t, p.TryComplete(gs.Failure[int](gs.ErrEmpty)))
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), p.Future().Wait())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p = future.NewPromise[int](ctx)
+	cancel()
+	assert.True(t, errors.Is(p.Success(1), context.Canceled))
+	_, completed := p.Future().Get()
+	assert.False(t, completed)
+
+	p = future.NewPromise[int](context.Background())
+	p.CompleteWith(future.Run(context.Background(), funcs.Id(5)))
+	assertResult(t, gs.Success(5), p.Future().Wait())
+}
+
+func TestSequence(t *testing.T) {
+	ctx := context.Background()
+	sleep := func(d time.Duration, v int) gs.Future[int] {
+		return future.Run(ctx, func() int {
+			time.Sleep(d)
+			return v
+		})
+	}
+
+	f := future.Sequence(ctx, slices.From(
+		sleep(300*time.Millisecond, 1),
+		sleep(100*time.Millisecond, 2),
+		sleep(200*time.Millisecond, 3),
+	))
+	assertResult(t, gs.Success(slices.From(1, 2, 3)), f.Wait())
+
+	f = future.Sequence(ctx, slices.Empty[gs.Future[int]]())
+	assertResult(t, gs.Success(slices.Empty[int]()), f.Wait())
+
+	slow := sleep(5*time.Second, 1)
+	f = future.Sequence(ctx, slices.From(
+		slow,
+		future.Try(ctx, func() (int, error) { return 0, gs.ErrEmpty }),
+	))
+	assertResult(t, gs.Failure[slices.S[int]](gs.ErrEmpty), f.Wait())
+	<-slow.Done()
+	assert.False(t, slow.Completed())
+
+	timeout, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	f = future.Sequence(timeout, slices.From(sleep(5*time.Second, 1)))
+	<-f.Done()
+	assert.False(t, f.Completed())
+}
+
+func TestSequenceAll(t *testing.T) {
+	ctx := context.Background()
+
+	f := future.SequenceAll(ctx, slices.From(
+		future.Run(ctx, funcs.Id(1)),
+		future.Try(ctx, func() (int, error) { return 0, gs.ErrEmpty }),
+		future.Run(ctx, funcs.Id(3)),
+	))
+	result := f.Wait().Get()
+	assert.Equal(t, 3, len(result))
+	assertResult(t, gs.Success(1), result[0])
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), result[1])
+	assertResult(t, gs.Success(3), result[2])
+}
+
+func TestTraverse(t *testing.T) {
+	ctx := context.Background()
+	op := func(v int) gs.Future[string] {
+		return future.Try(ctx, func() (string, error) {
+			time.Sleep(time.Duration(10-v) * 10 * time.Millisecond)
+			if v < 0 {
+				return "", gs.ErrUnsatisfied
+			}
+			return strconv.Itoa(v), nil
+		})
+	}
+
+	f := future.Traverse(ctx, slices.From(1, 2, 3, 4, 5), op)
+	assertResult(t, gs.Success(slices.From("1", "2", "3", "4", "5")), f.Wait())
+
+	f = future.Traverse(ctx, slices.From(1, -2, 3), op)
+	assertResult(t, gs.Failure[slices.S[string]](gs.ErrUnsatisfied), f.Wait())
+
+	g := future.TraverseAll(ctx, slices.From(1, -2, 3), op)
+	result := g.Wait().Get()
+	assertResult(t, gs.Success("1"), result[0])
+	assertResult(t, gs.Failure[string](gs.ErrUnsatisfied), result[1])
+	assertResult(t, gs.Success("3"), result[2])
+}
+
+func TestFirstCompletedOf(t *testing.T) {
+	ctx := context.Background()
+
+	slow := future.Run(ctx, func() int {
+		time.Sleep(5 * time.Second)
+		return 1
+	})
+	fast := future.Try(ctx, func() (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 0, gs.ErrEmpty
+	})
+
+	f := future.FirstCompletedOf(ctx, slow, fast)
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), f.Wait())
+	<-slow.Done()
+	assert.False(t, slow.Completed())
+
+	f = future.FirstCompletedOf[int](ctx)
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), f.Wait())
+}
+
+func TestFirstSuccessOf(t *testing.T) {
+	ctx := context.Background()
+	errFast := errors.New("fast")
+	errSlow := errors.New("slow")
+
+	fail := func(d time.Duration, err error) gs.Future[int] {
+		return future.Try(ctx, func() (int, error) {
+			time.Sleep(d)
+			return 0, err
+		})
+	}
+
+	slow := future.Run(ctx, func() int {
+		time.Sleep(5 * time.Second)
+		return 1
+	})
+	f := future.FirstSuccessOf(ctx, slices.From(
+		fail(100*time.Millisecond, errFast),
+		future.Run(ctx, func() int {
+			time.Sleep(300 * time.Millisecond)
+			return 2
+		}),
+		slow,
+	)...)
+	assertResult(t, gs.Success(2), f.Wait())
+	<-slow.Done()
+	assert.False(t, slow.Completed())
+
+	f = future.FirstSuccessOf(ctx,
+		fail(100*time.Millisecond, errFast),
+		fail(200*time.Millisecond, errSlow),
+	)
+	result := f.Wait()
+	assert.True(t, result.IsFailure())
+	assert.True(t, errors.Is(result.Failed(), errFast))
+	assert.True(t, errors.Is(result.Failed(), errSlow))
+
+	var errs future.Errors
+	assert.True(t, errors.As(result.Failed(), &errs))
+	assert.Equal(t, future.Errors{errFast, errSlow}, errs)
+}
+
+// nilFuture is a Future not built in package future, returning nil result after canceled.
+type nilFuture[T any] struct {
+	gs.Future[T]
+}
+
+func (nilFuture[T]) Get() (gs.Try[T], bool) {
+	return nil, false
+}
+
+func TestNilResult(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	f := future.Run(canceled, funcs.Id(1))
+	<-f.Done()
+	foreign := gs.Future[int](nilFuture[int]{f})
+
+	ctx := context.Background()
+	assertResult(t,
+		gs.Failure[slices.S[int]](gs.ErrEmpty),
+		future.Sequence(ctx, slices.From(future.Run(ctx, funcs.Id(1)), foreign)).Wait(),
+	)
+
+	all := future.SequenceAll(ctx, slices.From(foreign)).Wait().Get()
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), all[0])
+
+	assert.True(t, errors.Is(future.FirstCompletedOf(ctx, foreign).Wait().Failed(), gs.ErrEmpty))
+	assert.True(t, errors.Is(future.FirstSuccessOf(ctx, foreign).Wait().Failed(), gs.ErrEmpty))
+}
+
+func TestRunOn(t *testing.T) {
+	ctx := context.Background()
+
+	f := future.RunOn(ctx, future.Immediate, funcs.Id(1))
+	assert.True(t, f.Completed())
+	assertResult(t, gs.Success(1), f.Wait())
+
+	f = future.RunOn(ctx, future.Immediate, func() int { panic(gs.ErrEmpty) })
+	assert.True(t, f.Completed())
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), f.Wait())
+
+	f = future.TryOn(ctx, future.Unbounded, func() (int, error) { return 0, gs.ErrEmpty })
+	assertResult(t, gs.Failure[int](gs.ErrEmpty), f.Wait())
+}
+
+func TestPool(t *testing.T) {
+	const n = 50
+	ctx := context.Background()
+
+	pool := future.NewPool(4, n, future.Block)
+	defer pool.Close()
+
+	var running, max int32
+	op := func(v int) func() int {
+		return func() int {
+			cur := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&max)
+				if cur <= old || atomic.CompareAndSwapInt32(&max, old, cur) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return v
+		}
+	}
+
+	fs := slices.Tabulate(n, func(i int) gs.Future[int] {
+		return future.RunOn(ctx, pool, op(i))
+	})
+	result := future.Sequence(ctx, fs).Wait()
+	assertResult(t, gs.Success(slices.Range(0, n, 1)), result)
+	assert.LessOrEqual(t, atomic.LoadInt32(&max), int32(4))
+
+	g := future.MapOn(ctx, pool, fs[1], func(v int) string { return strconv.Itoa(v) })
+	assertResult(t, gs.Success("1"), g.Wait())
+
+	h := future.FlatMapOn(ctx, pool, fs[2], func(v int) gs.Future[int] {
+		return future.RunOn(ctx, future.Immediate, funcs.Id(v*10))
+	})
+	assertResult(t, gs.Success(20), h.Wait())
+}
+
+func TestPoolPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	block := make(chan struct{})
+	wait := func() int {
+		started <- struct{}{}
+		<-block
+		return 0
+	}
+
+	pool := future.NewPool(1, 1, future.Reject)
+	defer pool.Close()
+	busy := future.RunOn(ctx, pool, wait)
+	<-started
+	queued := future.RunOn(ctx, pool, funcs.Id(1))
+	f := future.RunOn(ctx, pool, funcs.Id(2))
+	assertResult(t, gs.Failure[int](future.ErrRejected), f.Wait())
+
+	caller := future.NewPool(1, 1, future.CallerRuns)
+	defer caller.Close()
+	busy2 := future.RunOn(ctx, caller, wait)
+	<-started
+	queued2 := future.RunOn(ctx, caller, funcs.Id(1))
+	f = future.RunOn(ctx, caller, funcs.Id(2))
+	assert.True(t, f.Completed())
+	assertResult(t, gs.Success(2), f.Wait())
+
+	close(block)
+	assertResult(t, gs.Success(0), busy.Wait())
+	assertResult(t, gs.Success(1), queued.Wait())
+	assertResult(t, gs.Success(0), busy2.Wait())
+	assertResult(t, gs.Success(1), queued2.Wait())
+
+	pool.Close()
+	f = future.RunOn(ctx, pool, funcs.Id(3))
+	assertResult(t, gs.Failure[int](future.ErrRejected), f.Wait())
+}
+
+func TestPoolPanic(t *testing.T) {
+	pool := future.NewPool(1, 1, future.Block)
+	defer pool.Close()
+
+	done := make(chan struct{})
+	assert.Nil(t, pool.Execute(func() { panic("boom") }))
+	assert.Nil(t, pool.Execute(func() { close(done) }))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "worker stopped after a panic")
+	}
+	errs := make(chan error, 1)
+	pool.OnPanic(func(err error) { errs <- err })
+	assert.Nil(t, pool.Execute(func() { panic("handled") }))
+
+	select {
+	case err := <-errs:
+		var perr *gs.PanicError
+		assert.True(t, errors.As(err, &perr))
+		assert.Equal(t, "handled", perr.Value)
+	case <-time.After(time.Second):
+		assert.Fail(t, "panic is not passed to the handler")
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	pool := future.NewPool(1, 0, future.Block)
+
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	assert.Nil(t, pool.Execute(func() {
+		close(started)
+		// blocks because the only worker is running this task.
+		result <- pool.Execute(func() {})
+	}))
+
+	<-started
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, future.ErrRejected)
+	case <-time.After(time.Second):
+		assert.Fail(t, "blocked caller is not rejected by Close")
+	}
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		assert.Fail(t, "Close does not return")
+	}
+}
+
+func TestPanic(t *testing.T) {
+	boom := func() int { panic("boom") }
+
+	sync := try.Catch(boom)
+	async := future.Run(context.Background(), boom).Wait()
+
+	var serr, aerr *gs.PanicError
+	assert.True(t, errors.As(sync.Failed(), &serr))
+	assert.True(t, errors.As(async.Failed(), &aerr))
+	assert.Equal(t, serr.Value, aerr.Value)
+	assert.Equal(t, serr.Error(), aerr.Error())
+
+	f := future.Map(context.Background(), future.Run(context.Background(), funcs.Id(1)), func(int) int {
+		panic("map")
+	})
+	assert.True(t, errors.As(f.Wait().Failed(), &aerr))
+	assert.Equal(t, "map", aerr.Value)
+	assert.PanicsWithValue(t, "map", func() { try.Must(f.Wait()) })
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package future
+
+import (
+	"context"
+
+	"github.com/dairaga/gs"
+)
+
+// Promise imitates Scala Promise. It is a writable and single-assignment container completing a Future.
+// Promise is completed at most once; completing a completed Promise returns ErrCompleted and keeps the first result.
+type Promise[T any] struct {
+	_ struct{}
+	f *F[T]
+}
+
+// NewPromise returns a Promise whose Future is canceled when given parent context is done.
+func NewPromise[T any](parent context.Context) *Promise[T] {
+	return &Promise[T]{
+		f: promise[T](parent),
+	}
+}
+
+// Future returns the Future completed by this.
+func (p *Promise[T]) Future() gs.Future[T] {
+	return p.f
+}
+
+// IsCompleted returns true if this has been completed.
+func (p *Promise[T]) IsCompleted() bool {
+	return p.f.Completed()
+}
+
+// Complete completes this with given result x.
+// It returns ErrCompleted if this has been completed, or error from context if this has been canceled.
+func (p *Promise[T]) Complete(x gs.Try[T]) error {
+	return p.f.complete(x)
+}
+
+// TryComplete completes this with given result x, and returns false if this has been completed or canceled.
+func (p *Promise[T]) TryComplete(x gs.Try[T]) bool {
+	return p.f.complete(x) == nil
+}
+
+// Success completes this with given successful value v.
+func (p *Promise[T]) Success(v T) error {
+	return p.Complete(gs.Success(v))
+}
+
+// Failure completes this with given error err.
+func (p *Promise[T]) Failure(err error) error {
+	return p.Complete(gs.Failure[T](err))
+}
+
+// CompleteWith completes this with the result of given Future f when f is completed.
+func (p *Promise[T]) CompleteWith(f gs.Future[T]) *Promise[T] {
+	f.OnCompleted(func(x gs.Try[T]) {
+		p.TryComplete(x)
+	})
+	return p
+}
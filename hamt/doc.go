@@ -0,0 +1,12 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+/*
+Package hamt implements a persistent immutable hash map like HashMap in Scala.
+
+A map is a hash array mapped trie (HAMT). Updated and Removed return a new map sharing unchanged nodes with the receiver,
+and never modify the receiver, so a map can be read by many goroutines without locks.
+*/
+package hamt
@@ -0,0 +1,356 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package hamt
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/internal/hash"
+	"github.com/dairaga/gs/maps"
+	"github.com/dairaga/gs/slices"
+)
+
+// Map is a persistent immutable hash map. The zero value is an empty map.
+type Map[K comparable, V any] struct {
+	_    struct{}
+	root *node[K, V]
+	size int
+}
+
+// Empty returns an empty map.
+func Empty[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// From returns a map containing given pairs. Later pairs override former ones with the same key.
+func From[K comparable, V any](pairs ...maps.Pair[K, V]) Map[K, V] {
+	return Empty[K, V]().UpdatedAll(pairs...)
+}
+
+// FromMap returns a map containing elements of given m.
+func FromMap[K comparable, V any](m maps.M[K, V]) Map[K, V] {
+	return maps.Fold(m, Empty[K, V](), func(z Map[K, V], k K, v V) Map[K, V] {
+		return z.Updated(k, v)
+	})
+}
+
+// Len returns numbers of elements in m.
+func (m Map[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if m has no element.
+func (m Map[K, V]) IsEmpty() bool {
+	return m.size <= 0
+}
+
+// Get returns Some with value of given key, or returns None if m does not have the key.
+func (m Map[K, V]) Get(key K) gs.Option[V] {
+	if e := m.root.find(0, hash.Of(key), key); e != nil {
+		return gs.Some(e.value)
+	}
+	return gs.None[V]()
+}
+
+// Contain returns true if m has given key x.
+func (m Map[K, V]) Contain(x K) bool {
+	return m.root.find(0, hash.Of(x), x) != nil
+}
+
+// Updated returns a new map with given key and value added. m is not changed.
+func (m Map[K, V]) Updated(key K, val V) Map[K, V] {
+	root, added := m.root.put(0, entry[K, V]{hash: hash.Of(key), key: key, value: val})
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// UpdatedAll returns a new map with given pairs added. m is not changed.
+func (m Map[K, V]) UpdatedAll(pairs ...maps.Pair[K, V]) Map[K, V] {
+	for _, p := range pairs {
+		m = m.Updated(p.Key, p.Value)
+	}
+	return m
+}
+
+// Concat returns a new map with all elements of m and a. Values in a override values in m with the same key.
+func (m Map[K, V]) Concat(a Map[K, V]) Map[K, V] {
+	return Fold(a, m, func(z Map[K, V], k K, v V) Map[K, V] {
+		return z.Updated(k, v)
+	})
+}
+
+// Removed returns a new map without given keys. m is not changed.
+func (m Map[K, V]) Removed(keys ...K) Map[K, V] {
+	for _, key := range keys {
+		if root := m.root.remove(0, hash.Of(key), key); root != m.root {
+			m.root = root
+			m.size--
+		}
+	}
+	return m
+}
+
+// Keys returns a slice of all keys.
+func (m Map[K, V]) Keys() slices.S[K] {
+	return Fold(m, make(slices.S[K], 0, m.size), func(z slices.S[K], k K, _ V) slices.S[K] {
+		return append(z, k)
+	})
+}
+
+// Values returns a slice of all values.
+func (m Map[K, V]) Values() slices.S[V] {
+	return Fold(m, make(slices.S[V], 0, m.size), func(z slices.S[V], _ K, v V) slices.S[V] {
+		return append(z, v)
+	})
+}
+
+// Count returns numbers of elements satisfying given function p.
+func (m Map[K, V]) Count(p func(K, V) bool) int {
+	return Fold(m, 0, func(z int, k K, v V) int {
+		return funcs.Cond(p(k, v), z+1, z)
+	})
+}
+
+// Find returns Some with the first key-value pair satisfying given function p, or returns None if not found.
+func (m Map[K, V]) Find(p func(K, V) bool) gs.Option[maps.Pair[K, V]] {
+	ret := gs.None[maps.Pair[K, V]]()
+	m.root.each(func(e *entry[K, V]) bool {
+		if p(e.key, e.value) {
+			ret = gs.Some(maps.P(e.key, e.value))
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// Exists returns true if at least one element satisfies given function p.
+func (m Map[K, V]) Exists(p func(K, V) bool) bool {
+	return !m.root.each(func(e *entry[K, V]) bool { return !p(e.key, e.value) })
+}
+
+// Forall returns true if m is empty, or all elements satisfy given function p.
+func (m Map[K, V]) Forall(p func(K, V) bool) bool {
+	return m.root.each(func(e *entry[K, V]) bool { return p(e.key, e.value) })
+}
+
+// Foreach applies given function op to all elements in m.
+func (m Map[K, V]) Foreach(op func(K, V)) {
+	m.root.each(func(e *entry[K, V]) bool {
+		op(e.key, e.value)
+		return true
+	})
+}
+
+// Filter returns a new map containing all elements satisfying given function p.
+func (m Map[K, V]) Filter(p func(K, V) bool) Map[K, V] {
+	return Fold(m, m, func(z Map[K, V], k K, v V) Map[K, V] {
+		return funcs.Cond(p(k, v), z, z.Removed(k))
+	})
+}
+
+// FilterNot returns a new map containing all elements not satisfying given function p.
+func (m Map[K, V]) FilterNot(p func(K, V) bool) Map[K, V] {
+	return m.Filter(func(k K, v V) bool { return !p(k, v) })
+}
+
+// Partition partitions m into two maps according to given function p.
+// The first one contains all elements not satisfying p, and the second one contains all elements satisfying p.
+func (m Map[K, V]) Partition(p func(K, V) bool) (Map[K, V], Map[K, V]) {
+	return m.FilterNot(p), m.Filter(p)
+}
+
+// Slice returns a slice containing key-value pairs of m.
+func (m Map[K, V]) Slice() slices.S[maps.Pair[K, V]] {
+	return Fold(m, make(slices.S[maps.Pair[K, V]], 0, m.size), func(z slices.S[maps.Pair[K, V]], k K, v V) slices.S[maps.Pair[K, V]] {
+		return append(z, maps.P(k, v))
+	})
+}
+
+// M returns a builtin map containing all elements of m.
+func (m Map[K, V]) M() maps.M[K, V] {
+	return Fold(m, make(maps.M[K, V], m.size), func(z maps.M[K, V], k K, v V) maps.M[K, V] {
+		return z.Put(k, v)
+	})
+}
+
+func (m Map[K, V]) String() string {
+	return fmt.Sprintf(`HashMap(%s)`, strings.Join(MapSlice(m, func(k K, v V) string {
+		return fmt.Sprintf(`%v -> %v`, k, v)
+	}), ", "))
+}
+
+// -----------------------------------------------------------------------------
+
+// Difference is changes from an old map to a new map.
+type Difference[K comparable, V any] struct {
+	_ struct{}
+
+	// Added contains elements only in the new map.
+	Added Map[K, V]
+
+	// Removed contains elements only in the old map.
+	Removed Map[K, V]
+
+	// Changed contains pairs of old and new values of keys in both maps but with different values.
+	Changed Map[K, gs.Tuple2[V, V]]
+}
+
+// IsEmpty returns true if there is no change.
+func (d Difference[K, V]) IsEmpty() bool {
+	return d.Added.IsEmpty() && d.Removed.IsEmpty() && d.Changed.IsEmpty()
+}
+
+// differ collects changes between two maps. It skips nodes shared by both.
+type differ[K comparable, V any] struct {
+	_    struct{}
+	eq   funcs.Equal[V, V]
+	diff Difference[K, V]
+}
+
+func (d *differ[K, V]) added(e *entry[K, V]) bool {
+	d.diff.Added = d.diff.Added.Updated(e.key, e.value)
+	return true
+}
+
+func (d *differ[K, V]) removed(e *entry[K, V]) bool {
+	d.diff.Removed = d.diff.Removed.Updated(e.key, e.value)
+	return true
+}
+
+// entries compares given entries at given shift.
+func (d *differ[K, V]) entries(shift uint, a, b *entry[K, V]) {
+	switch {
+	case a.child != nil && b.child != nil:
+		d.nodes(shift+width, a.child, b.child)
+	case a.child != nil:
+		d.nodes(shift+width, a.child, single(shift+width, *b))
+	case b.child != nil:
+		d.nodes(shift+width, single(shift+width, *a), b.child)
+	case a.key != b.key:
+		d.removed(a)
+		d.added(b)
+	case !d.eq(a.value, b.value):
+		d.diff.Changed = d.diff.Changed.Updated(a.key, gs.T2(a.value, b.value))
+	}
+}
+
+// nodes compares given nodes at given shift.
+func (d *differ[K, V]) nodes(shift uint, a, b *node[K, V]) {
+	if a == b {
+		return
+	}
+
+	if shift >= maxShift {
+		d.lookup(shift, a, b)
+		return
+	}
+
+	for bitmap := a.bitmap | b.bitmap; bitmap != 0; bitmap &= bitmap - 1 {
+		bit := bitmap & -bitmap
+		switch {
+		case a.bitmap&bit == 0:
+			d.each(&b.entries[bits.OnesCount32(b.bitmap&(bit-1))], d.added)
+		case b.bitmap&bit == 0:
+			d.each(&a.entries[bits.OnesCount32(a.bitmap&(bit-1))], d.removed)
+		default:
+			d.entries(shift, &a.entries[bits.OnesCount32(a.bitmap&(bit-1))], &b.entries[bits.OnesCount32(b.bitmap&(bit-1))])
+		}
+	}
+}
+
+// lookup compares given nodes by looking up keys of one node in the other one.
+func (d *differ[K, V]) lookup(shift uint, a, b *node[K, V]) {
+	a.each(func(x *entry[K, V]) bool {
+		y := b.find(shift, x.hash, x.key)
+		switch {
+		case y == nil:
+			d.removed(x)
+		case !d.eq(x.value, y.value):
+			d.diff.Changed = d.diff.Changed.Updated(x.key, gs.T2(x.value, y.value))
+		}
+		return true
+	})
+
+	b.each(func(y *entry[K, V]) bool {
+		if a.find(shift, y.hash, y.key) == nil {
+			d.added(y)
+		}
+		return true
+	})
+}
+
+// each applies given function op to all pair entries under given entry e.
+func (d *differ[K, V]) each(e *entry[K, V], op func(*entry[K, V]) bool) {
+	if e.child != nil {
+		e.child.each(op)
+	} else {
+		op(e)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// Fold applies given function op to a start value z and all elements of m.
+func Fold[K comparable, V, U any](m Map[K, V], z U, op func(U, K, V) U) U {
+	m.Foreach(func(k K, v V) {
+		z = op(z, k, v)
+	})
+	return z
+}
+
+// MapSlice returns a slice by applying given function op to all elements of m.
+func MapSlice[K comparable, V, T any](m Map[K, V], op func(K, V) T) slices.S[T] {
+	return Fold(m, make(slices.S[T], 0, m.size), func(z slices.S[T], k K, v V) slices.S[T] {
+		return append(z, op(k, v))
+	})
+}
+
+// Transform returns a new map by applying given function op to all elements of m. It is Map function of package maps,
+// and is named Transform because Map is the type.
+func Transform[K1, K2 comparable, V1, V2 any](m Map[K1, V1], op func(K1, V1) (K2, V2)) Map[K2, V2] {
+	return Fold(m, Empty[K2, V2](), func(z Map[K2, V2], k K1, v V1) Map[K2, V2] {
+		return z.Updated(op(k, v))
+	})
+}
+
+// GroupBy partitions m into a map of maps according to given discriminator function key.
+func GroupBy[K, K1 comparable, V any](m Map[K, V], key func(K, V) K1) Map[K1, Map[K, V]] {
+	return Fold(m, Empty[K1, Map[K, V]](), func(z Map[K1, Map[K, V]], k K, v V) Map[K1, Map[K, V]] {
+		k1 := key(k, v)
+		group := z.Get(k1).GetOrElse(Empty[K, V]())
+		return z.Updated(k1, group.Updated(k, v))
+	})
+}
+
+// DiffFunc returns changes from map a to map b. Values are compared with given function eq.
+// Nodes shared by a and b are skipped, so it is fast for a version derived from another one.
+func DiffFunc[K comparable, V any](a, b Map[K, V], eq funcs.Equal[V, V]) Difference[K, V] {
+	d := &differ[K, V]{eq: eq}
+	switch {
+	case a.root == nil:
+		b.root.each(d.added)
+	case b.root == nil:
+		a.root.each(d.removed)
+	default:
+		d.nodes(0, a.root, b.root)
+	}
+	return d.diff
+}
+
+// Diff returns changes from map a to map b.
+func Diff[K, V comparable](a, b Map[K, V]) Difference[K, V] {
+	return DiffFunc(a, b, funcs.Same[V])
+}
@@ -0,0 +1,250 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package hamt_test
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/quick"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/hamt"
+	"github.com/dairaga/gs/maps"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	even = func(k string, v int) bool { return (v & 0x01) == 0 }
+)
+
+func numbers(n int) hamt.Map[string, int] {
+	ret := hamt.Empty[string, int]()
+	for i := 0; i < n; i++ {
+		ret = ret.Updated(strconv.Itoa(i), i)
+	}
+	return ret
+}
+
+func TestFrom(t *testing.T) {
+	m := hamt.From(maps.P("a", 1), maps.P("b", 2), maps.P("a", 3))
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, maps.From(maps.P("a", 3), maps.P("b", 2)), m.M())
+
+	src := maps.From(maps.P("a", 1), maps.P("b", 2))
+	m = hamt.FromMap(src)
+	src["c"] = 3
+	assert.Equal(t, maps.From(maps.P("a", 1), maps.P("b", 2)), m.M())
+
+	var zero hamt.Map[string, int]
+	assert.True(t, zero.IsEmpty())
+	assert.True(t, zero.Get("a").IsEmpty())
+	assert.Equal(t, 1, zero.Updated("a", 1).Len())
+	assert.True(t, zero.Removed("a").IsEmpty())
+}
+
+func TestPersistent(t *testing.T) {
+	m1 := numbers(1000)
+	m2 := m1.Updated("1", -1).Updated("x", 1000)
+	m3 := m2.Removed("2", "3", "not exist")
+
+	assert.Equal(t, 1000, m1.Len())
+	assert.Equal(t, 1001, m2.Len())
+	assert.Equal(t, 999, m3.Len())
+
+	assert.Equal(t, gs.Some(1), m1.Get("1"))
+	assert.Equal(t, gs.Some(-1), m2.Get("1"))
+	assert.False(t, m1.Contain("x"))
+	assert.True(t, m2.Contain("x"))
+	assert.True(t, m2.Contain("2"))
+	assert.False(t, m3.Contain("2"))
+
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, gs.Some(i), m1.Get(strconv.Itoa(i)))
+	}
+
+	m4 := m1
+	for i := 0; i < 1000; i++ {
+		m4 = m4.Removed(strconv.Itoa(i))
+	}
+	assert.True(t, m4.IsEmpty())
+	assert.Equal(t, 1000, m1.Len())
+}
+
+func TestCollision(t *testing.T) {
+	// fields of these keys are hashed as the same bytes.
+	type key struct{ a, b string }
+	keys := []key{{"abc", ""}, {"ab", "c"}, {"a", "bc"}, {"", "abc"}}
+
+	m := hamt.Empty[key, int]()
+	for i, k := range keys {
+		m = m.Updated(k, i)
+	}
+	assert.Equal(t, len(keys), m.Len())
+	for i, k := range keys {
+		assert.Equal(t, gs.Some(i), m.Get(k))
+	}
+	assert.False(t, m.Contain(key{"abc", "abc"}))
+
+	m2 := m.Updated(keys[1], 100)
+	assert.Equal(t, len(keys), m2.Len())
+	assert.Equal(t, gs.Some(100), m2.Get(keys[1]))
+	assert.Equal(t, gs.Some(1), m.Get(keys[1]))
+
+	m3 := m2.Removed(keys[0], keys[2], keys[3])
+	assert.Equal(t, 1, m3.Len())
+	assert.Equal(t, gs.Some(100), m3.Get(keys[1]))
+	assert.True(t, m3.Removed(keys[1]).IsEmpty())
+
+	d := hamt.Diff(m, m3.Updated(key{"x", ""}, 9))
+	assert.Equal(t, maps.From(maps.P(key{"x", ""}, 9)), d.Added.M())
+	assert.Equal(t, 3, d.Removed.Len())
+	assert.Equal(t, gs.Some(gs.T2(1, 100)), d.Changed.Get(keys[1]))
+
+	d = hamt.Diff(m3, m)
+	assert.Equal(t, 3, d.Added.Len())
+	assert.True(t, d.Removed.IsEmpty())
+	assert.Equal(t, gs.Some(gs.T2(100, 1)), d.Changed.Get(keys[1]))
+}
+
+func TestKeys(t *testing.T) {
+	type key struct {
+		a string
+		b int
+		c bool
+		d [2]float64
+		p *int
+	}
+
+	x := 1
+	m := hamt.From(
+		maps.P(key{a: "a", b: 1, c: true, d: [2]float64{0, 1}, p: &x}, 1),
+		maps.P(key{a: "a", b: 1, c: false}, 2),
+	)
+	assert.Equal(t, gs.Some(1), m.Get(key{a: "a", b: 1, c: true, d: [2]float64{math.Copysign(0, -1), 1}, p: &x}))
+	assert.Equal(t, gs.Some(2), m.Get(key{a: "a", b: 1, c: false}))
+	assert.True(t, m.Get(key{a: "a", b: 2}).IsEmpty())
+}
+
+func TestMethods(t *testing.T) {
+	m := numbers(10)
+
+	assert.ElementsMatch(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, m.Keys())
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, m.Values())
+	assert.Equal(t, 5, m.Count(even))
+	assert.Equal(t, gs.Some(maps.P("3", 3)), m.Find(func(_ string, v int) bool { return v == 3 }))
+	assert.True(t, m.Find(func(_ string, v int) bool { return v > 9 }).IsEmpty())
+	assert.True(t, m.Exists(even))
+	assert.False(t, m.Exists(func(_ string, v int) bool { return v > 9 }))
+	assert.True(t, m.Forall(func(_ string, v int) bool { return v < 10 }))
+	assert.False(t, m.Forall(even))
+
+	sum := 0
+	m.Foreach(func(_ string, v int) { sum += v })
+	assert.Equal(t, 45, sum)
+
+	assert.Equal(t, maps.From(maps.P("0", 0), maps.P("2", 2), maps.P("4", 4), maps.P("6", 6), maps.P("8", 8)), m.Filter(even).M())
+	assert.Equal(t, maps.From(maps.P("1", 1), maps.P("3", 3), maps.P("5", 5), maps.P("7", 7), maps.P("9", 9)), m.FilterNot(even).M())
+	a, b := m.Partition(even)
+	assert.Equal(t, m.FilterNot(even).M(), a.M())
+	assert.Equal(t, m.Filter(even).M(), b.M())
+	assert.Equal(t, 10, m.Len())
+
+	assert.ElementsMatch(t, m.M().Slice(), m.Slice())
+	assert.Equal(t, maps.From(maps.P("0", 0), maps.P("1", -1), maps.P("2", 2)), numbers(2).Concat(hamt.From(maps.P("1", -1), maps.P("2", 2))).M())
+
+	assert.Equal(t, "HashMap(a -> 1)", hamt.From(maps.P("a", 1)).String())
+	assert.Equal(t, "HashMap()", hamt.Empty[string, int]().String())
+}
+
+func TestFunctions(t *testing.T) {
+	m := numbers(10)
+
+	assert.Equal(t, 45, hamt.Fold(m, 0, func(z int, _ string, v int) int { return z + v }))
+	assert.ElementsMatch(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, hamt.MapSlice(m, func(_ string, v int) int { return v * 2 }))
+
+	r := hamt.Transform(m, func(k string, v int) (int, string) { return v, k })
+	assert.Equal(t, 10, r.Len())
+	assert.Equal(t, gs.Some("7"), r.Get(7))
+
+	groups := hamt.GroupBy(m, even)
+	assert.Equal(t, 2, groups.Len())
+	assert.Equal(t, m.Filter(even).M(), groups.Get(true).Get().M())
+	assert.Equal(t, m.FilterNot(even).M(), groups.Get(false).Get().M())
+}
+
+func TestDiff(t *testing.T) {
+	old := numbers(5000)
+	cur := old.Updated("1", -1).Updated("x", 1).Removed("2", "4000").Updated("3", 3)
+
+	d := hamt.Diff(old, cur)
+	assert.Equal(t, maps.From(maps.P("x", 1)), d.Added.M())
+	assert.Equal(t, maps.From(maps.P("2", 2), maps.P("4000", 4000)), d.Removed.M())
+	assert.Equal(t, map[string]gs.Tuple2[int, int]{"1": gs.T2(1, -1)}, map[string]gs.Tuple2[int, int](d.Changed.M()))
+
+	assert.True(t, hamt.Diff(old, old).IsEmpty())
+	assert.Equal(t, old.M(), hamt.Diff(hamt.Empty[string, int](), old).Added.M())
+	assert.Equal(t, old.M(), hamt.Diff(old, hamt.Empty[string, int]()).Removed.M())
+
+	d = hamt.DiffFunc(old, cur, func(a, b int) bool { return funcs.Max(a, -a) == funcs.Max(b, -b) })
+	assert.True(t, d.Changed.IsEmpty())
+}
+
+func TestQuick(t *testing.T) {
+	diff := func(a, b map[uint8]int8) bool {
+		m1, m2 := hamt.FromMap(maps.M[uint8, int8](a)), hamt.Empty[uint8, int8]()
+		for k, v := range b {
+			m2 = m2.Updated(k, v)
+		}
+		m2 = m2.Filter(func(k uint8, _ int8) bool { return k&0x03 != 0 })
+		m3 := m1.Concat(m2).Removed(m2.Keys()[:m2.Len()/2]...)
+
+		added, removed, changed := maps.M[uint8, int8]{}, maps.M[uint8, int8]{}, maps.M[uint8, gs.Tuple2[int8, int8]]{}
+		for k, v := range m3.M() {
+			if x, ok := a[k]; !ok {
+				added[k] = v
+			} else if x != v {
+				changed[k] = gs.T2(x, v)
+			}
+		}
+		for k, v := range a {
+			if !m3.Contain(k) {
+				removed[k] = v
+			}
+		}
+
+		d := hamt.Diff(m1, m3)
+		return len(m1.M()) == len(a) &&
+			maps.Fold(m1.M(), true, func(z bool, k uint8, v int8) bool { return z && a[k] == v }) &&
+			len(added) == d.Added.Len() && len(removed) == d.Removed.Len() && len(changed) == d.Changed.Len() &&
+			added.Forall(func(k uint8, v int8) bool { return d.Added.Get(k).GetOrElse(^v) == v }) &&
+			removed.Forall(func(k uint8, v int8) bool { return d.Removed.Get(k).GetOrElse(^v) == v }) &&
+			changed.Forall(func(k uint8, v gs.Tuple2[int8, int8]) bool { return d.Changed.Get(k).GetOrElse(gs.T2(v.V2, v.V1)) == v })
+	}
+	assert.NoError(t, quick.Check(diff, &quick.Config{MaxCount: 300}))
+}
+
+func TestConcurrent(t *testing.T) {
+	m := numbers(1000)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			u := m
+			for j := 0; j < 1000; j++ {
+				k := strconv.Itoa(j)
+				assert.Equal(t, gs.Some(j), m.Get(k))
+				u = u.Updated(k, i).Removed(strconv.Itoa(j - 1))
+			}
+			assert.Equal(t, 1, u.Len())
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, m.Len())
+}
@@ -0,0 +1,225 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package hamt
+
+import "math/bits"
+
+const (
+	width = 5
+	mask  = 1<<width - 1
+
+	// maxShift is the shift of nodes holding keys with the same hash code.
+	maxShift = 65
+)
+
+// entry is an element of node. It is a sub node if child is not nil, or a key-value pair.
+type entry[K comparable, V any] struct {
+	_     struct{}
+	hash  uint64
+	key   K
+	value V
+	child *node[K, V]
+}
+
+// node is a node of hash array mapped trie.
+// Entries are indexed by bitmap, except nodes at maxShift keep colliding pairs in a list.
+type node[K comparable, V any] struct {
+	_       struct{}
+	bitmap  uint32
+	entries []entry[K, V]
+}
+
+// index returns bit and position in entries of given hash code h at given shift.
+func (n *node[K, V]) index(shift uint, h uint64) (uint32, int) {
+	bit := uint32(1) << ((h >> shift) & mask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// find returns the pair entry with given key k.
+func (n *node[K, V]) find(shift uint, h uint64, k K) *entry[K, V] {
+	for n != nil {
+		if shift >= maxShift {
+			for i := range n.entries {
+				if n.entries[i].key == k {
+					return &n.entries[i]
+				}
+			}
+			return nil
+		}
+
+		bit, pos := n.index(shift, h)
+		if n.bitmap&bit == 0 {
+			return nil
+		}
+
+		e := &n.entries[pos]
+		if e.child == nil {
+			if e.hash == h && e.key == k {
+				return e
+			}
+			return nil
+		}
+		n, shift = e.child, shift+width
+	}
+	return nil
+}
+
+// with returns a copy of n with entries replaced by given function op.
+func (n *node[K, V]) with(bitmap uint32, op func([]entry[K, V]) []entry[K, V]) *node[K, V] {
+	entries := make([]entry[K, V], len(n.entries), len(n.entries)+1)
+	copy(entries, n.entries)
+	return &node[K, V]{bitmap: bitmap, entries: op(entries)}
+}
+
+// pair returns a node at given shift containing given pair entries with different keys.
+func pair[K comparable, V any](shift uint, e1, e2 entry[K, V]) *node[K, V] {
+	if shift >= maxShift {
+		return &node[K, V]{entries: []entry[K, V]{e1, e2}}
+	}
+
+	b1, b2 := uint32(1)<<((e1.hash>>shift)&mask), uint32(1)<<((e2.hash>>shift)&mask)
+	if b1 == b2 {
+		return &node[K, V]{
+			bitmap:  b1,
+			entries: []entry[K, V]{{child: pair(shift+width, e1, e2)}},
+		}
+	}
+
+	if b1 > b2 {
+		e1, e2 = e2, e1
+	}
+	return &node[K, V]{bitmap: b1 | b2, entries: []entry[K, V]{e1, e2}}
+}
+
+// single returns a node at given shift containing given pair entry e only.
+func single[K comparable, V any](shift uint, e entry[K, V]) *node[K, V] {
+	ret := &node[K, V]{entries: []entry[K, V]{e}}
+	if shift < maxShift {
+		ret.bitmap = uint32(1) << ((e.hash >> shift) & mask)
+	}
+	return ret
+}
+
+// put returns a copy of n with given pair entry e, and true if the key of e is new.
+func (n *node[K, V]) put(shift uint, e entry[K, V]) (*node[K, V], bool) {
+	if n == nil {
+		n = &node[K, V]{}
+	}
+
+	if shift >= maxShift {
+		for i := range n.entries {
+			if n.entries[i].key == e.key {
+				return n.with(0, func(a []entry[K, V]) []entry[K, V] {
+					a[i] = e
+					return a
+				}), false
+			}
+		}
+		return n.with(0, func(a []entry[K, V]) []entry[K, V] { return append(a, e) }), true
+	}
+
+	bit, pos := n.index(shift, e.hash)
+	if n.bitmap&bit == 0 {
+		return n.with(n.bitmap|bit, func(a []entry[K, V]) []entry[K, V] {
+			a = append(a, entry[K, V]{})
+			copy(a[pos+1:], a[pos:])
+			a[pos] = e
+			return a
+		}), true
+	}
+
+	old := n.entries[pos]
+	var added bool
+	switch {
+	case old.child != nil:
+		old.child, added = old.child.put(shift+width, e)
+		e = old
+	case old.key != e.key:
+		e, added = entry[K, V]{child: pair(shift+width, old, e)}, true
+	}
+
+	return n.with(n.bitmap, func(a []entry[K, V]) []entry[K, V] {
+		a[pos] = e
+		return a
+	}), added
+}
+
+// remove returns a copy of n without given key k, or returns n itself if n does not have k.
+// It returns nil if the result is empty.
+func (n *node[K, V]) remove(shift uint, h uint64, k K) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	if shift >= maxShift {
+		for i := range n.entries {
+			if n.entries[i].key == k {
+				return n.without(0, i)
+			}
+		}
+		return n
+	}
+
+	bit, pos := n.index(shift, h)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+
+	e := n.entries[pos]
+	if e.child == nil {
+		if e.key != k {
+			return n
+		}
+		return n.without(bit, pos)
+	}
+
+	child := e.child.remove(shift+width, h, k)
+	switch {
+	case child == e.child:
+		return n
+	case child == nil:
+		return n.without(bit, pos)
+	case len(child.entries) == 1 && child.entries[0].child == nil:
+		// pull the only pair up to keep the trie compact.
+		e = child.entries[0]
+	default:
+		e.child = child
+	}
+
+	return n.with(n.bitmap, func(a []entry[K, V]) []entry[K, V] {
+		a[pos] = e
+		return a
+	})
+}
+
+// without returns a copy of n without the entry at given position pos and bit, or returns nil if the result is empty.
+func (n *node[K, V]) without(bit uint32, pos int) *node[K, V] {
+	if len(n.entries) <= 1 {
+		return nil
+	}
+	return n.with(n.bitmap&^bit, func(a []entry[K, V]) []entry[K, V] {
+		return append(a[:pos], a[pos+1:]...)
+	})
+}
+
+// each applies given function op to pair entries of n until op returns false.
+func (n *node[K, V]) each(op func(*entry[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	for i := range n.entries {
+		e := &n.entries[i]
+		if e.child != nil {
+			if !e.child.each(op) {
+				return false
+			}
+		} else if !op(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package hash computes hash codes of comparable values.
+package hash
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+var (
+	seed = maphash.MakeSeed()
+	salt = new(maphash.Hash).Sum64() // a zero Hash uses a random seed
+)
+
+// mix returns hash code of given integer x. It is a bijection, so different integers never collide.
+func mix(x uint64) uint64 {
+	// finalizer of MurmurHash3
+	x ^= salt
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Of returns hash code of given key k. Equal keys have the same hash code like keys of builtin map.
+// Hash codes are different between processes.
+func Of[K comparable](k K) uint64 {
+	switch x := interface{}(k).(type) {
+	case int:
+		return mix(uint64(x))
+	case int64:
+		return mix(uint64(x))
+	case uint64:
+		return mix(x)
+	case int32:
+		return mix(uint64(x))
+	case uint32:
+		return mix(uint64(x))
+	}
+
+	var h maphash.Hash
+	h.SetSeed(seed)
+	if x, ok := interface{}(k).(string); ok {
+		h.WriteString(x)
+	} else {
+		write(&h, reflect.ValueOf(&k).Elem())
+	}
+	return h.Sum64()
+}
+
+func writeUint64(h *maphash.Hash, x uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	h.Write(buf[:])
+}
+
+func writeFloat64(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0 // -0 == +0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
+
+// write writes value v into h in the way that equal values write the same bytes.
+func write(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat64(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat64(h, real(c))
+		writeFloat64(h, imag(c))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			write(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			write(h, v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+		} else {
+			// values of different dynamic types may write the same bytes, and they are told apart by ==.
+			write(h, v.Elem())
+		}
+	}
+}
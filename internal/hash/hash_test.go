@@ -0,0 +1,73 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package hash_test
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/dairaga/gs/internal/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	type key struct {
+		a string
+		b int8
+		c [2]float64
+		d *int
+	}
+
+	x := 1
+	assert.Equal(t, hash.Of("abc"), hash.Of("abc"))
+	assert.Equal(t, hash.Of(0.0), hash.Of(math.Copysign(0, -1)))
+	assert.Equal(t,
+		hash.Of(key{a: "a", b: 1, c: [2]float64{0, 1}, d: &x}),
+		hash.Of(key{a: "a", b: 1, c: [2]float64{math.Copysign(0, -1), 1}, d: &x}),
+	)
+
+	equal := func(a int) bool { return hash.Of(a) == hash.Of(a) }
+	assert.NoError(t, quick.Check(equal, nil))
+}
+
+func TestOfIntegers(t *testing.T) {
+	// integers are mixed by a bijection, so different integers never collide.
+	distinct := func(a, b int64) bool {
+		return a == b || hash.Of(a) != hash.Of(b)
+	}
+	assert.NoError(t, quick.Check(distinct, &quick.Config{MaxCount: 10000}))
+
+	seen := make(map[uint64]bool)
+	for i := 0; i < 1<<16; i++ {
+		h := hash.Of(i)
+		assert.False(t, seen[h])
+		seen[h] = true
+		assert.Equal(t, h, hash.Of(int64(i)))
+		assert.Equal(t, h, hash.Of(uint64(i)))
+	}
+
+	// low bits used to pick shards and trie branches are spread over consecutive integers.
+	buckets := make([]int, 32)
+	for i := 0; i < 32*1024; i++ {
+		buckets[hash.Of(i)%32]++
+	}
+	for _, n := range buckets {
+		assert.InDelta(t, 1024, n, 256)
+	}
+}
+
+func BenchmarkOfInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hash.Of(i)
+	}
+}
+
+func BenchmarkOfString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hash.Of("benchmark")
+	}
+}
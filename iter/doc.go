@@ -0,0 +1,9 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+/*
+Package iter provides lazy iterator. Transformations on iterator are fused and evaluated only when elements are pulled by a terminal operation.
+*/
+package iter
@@ -0,0 +1,395 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package iter
+
+import (
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/maps"
+	"github.com/dairaga/gs/slices"
+)
+
+// I is a lazy iterator. It returns next element and true, or returns false if there is no more element.
+// An iterator can be traversed only once.
+type I[T any] func() (T, bool)
+
+// Empty returns an iterator without any element.
+func Empty[T any]() I[T] {
+	return func() (v T, ok bool) {
+		return
+	}
+}
+
+// From returns an iterator over given elements.
+func From[T any](a ...T) I[T] {
+	return FromSlice(a)
+}
+
+// FromSlice returns an iterator over elements of given slice s.
+func FromSlice[T any](s slices.S[T]) I[T] {
+	i := 0
+	return func() (v T, ok bool) {
+		if i >= len(s) {
+			return
+		}
+		v = s[i]
+		i++
+		return v, true
+	}
+}
+
+// FromMap returns an iterator over key-value pairs of given map m. Keys are taken when the iterator is built.
+func FromMap[K comparable, V any](m maps.M[K, V]) I[maps.Pair[K, V]] {
+	keys := FromSlice(m.Keys())
+	return func() (p maps.Pair[K, V], ok bool) {
+		for {
+			k, more := keys()
+			if !more {
+				return
+			}
+			if v, found := m[k]; found {
+				return maps.P(k, v), true
+			}
+		}
+	}
+}
+
+// FromChan returns an iterator receiving elements from given channel ch until ch is closed.
+func FromChan[T any](ch <-chan T) I[T] {
+	return func() (T, bool) {
+		v, ok := <-ch
+		return v, ok
+	}
+}
+
+// Iterate returns an infinite iterator producing given start value z, op(z), op(op(z)), and so on.
+func Iterate[T any](z T, op funcs.Func[T, T]) I[T] {
+	next := z
+	return func() (T, bool) {
+		v := next
+		next = op(next)
+		return v, true
+	}
+}
+
+// Continually returns an infinite iterator producing results from given function op.
+func Continually[T any](op funcs.Unit[T]) I[T] {
+	return func() (T, bool) {
+		return op(), true
+	}
+}
+
+// Range returns an iterator producing equally spaced values in a given interval.
+func Range[T gs.Numeric](start, end, step T) I[T] {
+	next := start
+	return func() (v T, ok bool) {
+		if next >= end {
+			return
+		}
+		v = next
+		next += step
+		return v, true
+	}
+}
+
+// Filter returns a new iterator with elements satisfying given function p.
+func (it I[T]) Filter(p funcs.Predict[T]) I[T] {
+	return func() (T, bool) {
+		for {
+			v, ok := it()
+			if !ok || p(v) {
+				return v, ok
+			}
+		}
+	}
+}
+
+// FilterNot returns a new iterator with elements not satisfying given function p.
+func (it I[T]) FilterNot(p funcs.Predict[T]) I[T] {
+	return it.Filter(func(v T) bool { return !p(v) })
+}
+
+// TakeWhile returns a new iterator with the longest prefix of elements satisfying given function p.
+func (it I[T]) TakeWhile(p funcs.Predict[T]) I[T] {
+	done := false
+	return func() (v T, ok bool) {
+		if !done {
+			if v, ok = it(); ok && p(v) {
+				return
+			}
+			done = true
+		}
+		var zero T
+		return zero, false
+	}
+}
+
+// DropWhile returns a new iterator without the longest prefix of elements satisfying given function p.
+func (it I[T]) DropWhile(p funcs.Predict[T]) I[T] {
+	dropped := false
+	return func() (T, bool) {
+		if dropped {
+			return it()
+		}
+		dropped = true
+		for {
+			v, ok := it()
+			if !ok || !p(v) {
+				return v, ok
+			}
+		}
+	}
+}
+
+// Take returns a new iterator with first n elements.
+func (it I[T]) Take(n int) I[T] {
+	return func() (v T, ok bool) {
+		if n <= 0 {
+			return
+		}
+		n--
+		return it()
+	}
+}
+
+// Drop returns a new iterator without first n elements.
+func (it I[T]) Drop(n int) I[T] {
+	return func() (T, bool) {
+		for ; n > 0; n-- {
+			if _, ok := it(); !ok {
+				break
+			}
+		}
+		return it()
+	}
+}
+
+// Foreach applies given function op to all elements.
+func (it I[T]) Foreach(op func(T)) {
+	for v, ok := it(); ok; v, ok = it() {
+		op(v)
+	}
+}
+
+// Slice returns a slice containing all elements.
+func (it I[T]) Slice() slices.S[T] {
+	return Fold(it, slices.Empty[T](), func(z slices.S[T], v T) slices.S[T] {
+		return append(z, v)
+	})
+}
+
+// Head returns Some with next element, or returns None if there is no more element.
+func (it I[T]) Head() gs.Option[T] {
+	v, ok := it()
+	return funcs.Cond(ok, gs.Some(v), gs.None[T]())
+}
+
+// Find returns Some with the first element satisfying given function p, or returns None.
+func (it I[T]) Find(p funcs.Predict[T]) gs.Option[T] {
+	return it.Filter(p).Head()
+}
+
+// Exists returns true if at least one element satisfies given function p.
+func (it I[T]) Exists(p funcs.Predict[T]) bool {
+	return it.Find(p).IsDefined()
+}
+
+// Forall returns true if all elements satisfy given function p.
+func (it I[T]) Forall(p funcs.Predict[T]) bool {
+	return !it.Exists(func(v T) bool { return !p(v) })
+}
+
+// Count returns numbers of elements satisfying given function p.
+func (it I[T]) Count(p funcs.Predict[T]) int {
+	return Fold(it, 0, func(z int, v T) int {
+		return funcs.Cond(p(v), z+1, z)
+	})
+}
+
+// Reduce returns Some with value applying given function op to all elements from left to right, or returns None if there is no element.
+func (it I[T]) Reduce(op func(T, T) T) gs.Option[T] {
+	head, ok := it()
+	if !ok {
+		return gs.None[T]()
+	}
+	return gs.Some(Fold(it, head, op))
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// Map returns a new iterator applying given function op to all elements of it.
+func Map[T, U any](it I[T], op funcs.Func[T, U]) I[U] {
+	return func() (u U, ok bool) {
+		v, ok := it()
+		if !ok {
+			return
+		}
+		return op(v), true
+	}
+}
+
+// FlatMap returns a new iterator applying given function op to all elements of it and concatenating results.
+func FlatMap[T, U any](it I[T], op funcs.Func[T, I[U]]) I[U] {
+	cur := Empty[U]()
+	return func() (u U, ok bool) {
+		for {
+			if u, ok = cur(); ok {
+				return
+			}
+			v, more := it()
+			if !more {
+				return
+			}
+			cur = op(v)
+		}
+	}
+}
+
+// Collect returns a new iterator with results applying given partial function p to elements on which it is defined.
+func Collect[T, U any](it I[T], p funcs.Partial[T, U]) I[U] {
+	return func() (u U, ok bool) {
+		for {
+			v, more := it()
+			if !more {
+				return
+			}
+			if u, ok = p(v); ok {
+				return
+			}
+		}
+	}
+}
+
+// Zip returns a new iterator pairing elements of given a and b. It stops when either a or b is exhausted.
+func Zip[T, U any](a I[T], b I[U]) I[gs.Tuple2[T, U]] {
+	return func() (t gs.Tuple2[T, U], ok bool) {
+		v1, ok1 := a()
+		if !ok1 {
+			return
+		}
+		v2, ok2 := b()
+		if !ok2 {
+			return
+		}
+		return gs.T2(v1, v2), true
+	}
+}
+
+// Scan returns a new iterator producing given start value z and cumulative results of applying given function op to elements of it.
+func Scan[T, U any](it I[T], z U, op func(U, T) U) I[U] {
+	started := false
+	return func() (u U, ok bool) {
+		if !started {
+			started = true
+			return z, true
+		}
+		v, ok := it()
+		if !ok {
+			return
+		}
+		z = op(z, v)
+		return z, true
+	}
+}
+
+// Sliding returns a new iterator producing windows of given size and starting every step elements of it.
+// It follows slices.S.Sliding, and panics with gs.ErrUnsupported if size or step is not positive.
+func Sliding[T any](it I[T], size, step int) I[slices.S[T]] {
+	if size <= 0 || step <= 0 {
+		panic(gs.ErrUnsupported)
+	}
+
+	var prev slices.S[T]
+	done := false
+	return func() (slices.S[T], bool) {
+		if done {
+			return nil, false
+		}
+
+		ret := make(slices.S[T], 0, size)
+		if prev != nil && step < size {
+			ret = append(ret, prev[step:]...)
+		} else if prev != nil {
+			for i := size; i < step; i++ {
+				if _, ok := it(); !ok {
+					done = true
+					return nil, false
+				}
+			}
+		}
+
+		n := len(ret)
+		for len(ret) < size {
+			v, ok := it()
+			if !ok {
+				done = true
+				break
+			}
+			ret = append(ret, v)
+		}
+
+		if len(ret) == n {
+			done = true
+			return nil, false
+		}
+		prev = ret
+		return ret.Clone(), true
+	}
+}
+
+// Grouped returns a new iterator partitioning it into slices of given size n. The last slice may be shorter than n.
+// It panics with gs.ErrUnsupported if n is not positive.
+func Grouped[T any](it I[T], n int) I[slices.S[T]] {
+	return Sliding(it, n, n)
+}
+
+// ChunkWhile returns a new iterator partitioning it into runs of consecutive elements. A run continues while given function p returns true with the previous and current elements.
+func ChunkWhile[T any](it I[T], p func(T, T) bool) I[slices.S[T]] {
+	var (
+		next        T
+		ok, started bool
+	)
+	return func() (slices.S[T], bool) {
+		if !started {
+			started = true
+			next, ok = it()
+		}
+
+		if !ok {
+			return nil, false
+		}
+
+		ret := slices.One(next)
+		for next, ok = it(); ok && p(ret[len(ret)-1], next); next, ok = it() {
+			ret = append(ret, next)
+		}
+		return ret, true
+	}
+}
+
+// ChunkBy returns a new iterator partitioning it into runs of consecutive elements with the same key from given function key.
+func ChunkBy[T any, K comparable](it I[T], key funcs.Func[T, K]) I[slices.S[T]] {
+	return ChunkWhile(it, func(a, b T) bool {
+		return key(a) == key(b)
+	})
+}
+
+// Fold applies given function op to given start value z and all elements of it from left to right.
+func Fold[T, U any](it I[T], z U, op func(U, T) U) U {
+	for v, ok := it(); ok; v, ok = it() {
+		z = op(z, v)
+	}
+	return z
+}
+
+// ToMap returns a map containing all key-value pairs from it.
+func ToMap[K comparable, V any](it I[maps.Pair[K, V]]) maps.M[K, V] {
+	return Fold(it, make(maps.M[K, V]), func(z maps.M[K, V], p maps.Pair[K, V]) maps.M[K, V] {
+		return z.Put(p.Key, p.Value)
+	})
+}
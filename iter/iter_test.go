@@ -0,0 +1,207 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package iter_test
+
+import (
+	"strconv"
+	"testing"
+	"testing/quick"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/iter"
+	"github.com/dairaga/gs/maps"
+	"github.com/dairaga/gs/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	even = func(v int) bool { return (v & 0x01) == 0 }
+	odd  = func(v int) bool { return (v & 0x01) == 1 }
+)
+
+func TestSources(t *testing.T) {
+	assert.Equal(t, slices.Empty[int](), iter.Empty[int]().Slice())
+	assert.Equal(t, slices.From(1, 2, 3), iter.From(1, 2, 3).Slice())
+	assert.Equal(t, slices.From(1, 2, 3), iter.FromSlice(slices.From(1, 2, 3)).Slice())
+	assert.Equal(t, slices.From(0, 2, 4), iter.Range(0, 5, 2).Slice())
+	assert.Equal(t, slices.From(1, 2, 4, 8), iter.Iterate(1, func(v int) int { return v * 2 }).Take(4).Slice())
+
+	n := 0
+	assert.Equal(t,
+		slices.From(1, 2, 3),
+		iter.Continually(func() int { n++; return n }).Take(3).Slice(),
+	)
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	assert.Equal(t, slices.From(1, 2, 3), iter.FromChan(ch).Slice())
+
+	m := maps.From(maps.P(1, "1"), maps.P(2, "2"), maps.P(3, "3"))
+	assert.Equal(t, m, iter.ToMap(iter.FromMap(m)))
+}
+
+func TestFilter(t *testing.T) {
+	assert.Equal(t, slices.From(2, 4), iter.From(1, 2, 3, 4, 5).Filter(even).Slice())
+	assert.Equal(t, slices.From(1, 3, 5), iter.From(1, 2, 3, 4, 5).FilterNot(even).Slice())
+}
+
+func TestTakeDrop(t *testing.T) {
+	s := slices.From(1, 3, 5, 2, 4, 7)
+
+	assert.Equal(t, slices.From(1, 3, 5), iter.FromSlice(s).TakeWhile(odd).Slice())
+	assert.Equal(t, slices.From(2, 4, 7), iter.FromSlice(s).DropWhile(odd).Slice())
+	assert.Equal(t, slices.From(1, 3), iter.FromSlice(s).Take(2).Slice())
+	assert.Equal(t, slices.Empty[int](), iter.FromSlice(s).Take(0).Slice())
+	assert.Equal(t, s, iter.FromSlice(s).Take(100).Slice())
+	assert.Equal(t, slices.From(4, 7), iter.FromSlice(s).Drop(4).Slice())
+	assert.Equal(t, slices.Empty[int](), iter.FromSlice(s).Drop(100).Slice())
+
+	it := iter.FromSlice(s).TakeWhile(odd)
+	it.Slice()
+	_, ok := it()
+	assert.False(t, ok)
+}
+
+func TestTerminal(t *testing.T) {
+	assert.Equal(t, gs.Some(1), iter.From(1, 2).Head())
+	assert.True(t, iter.Empty[int]().Head().IsEmpty())
+	assert.Equal(t, gs.Some(2), iter.From(1, 2, 3).Find(even))
+	assert.True(t, iter.From(1, 3).Find(even).IsEmpty())
+	assert.True(t, iter.From(1, 2).Exists(even))
+	assert.False(t, iter.From(1, 2).Forall(even))
+	assert.True(t, iter.From(2, 4).Forall(even))
+	assert.Equal(t, 2, iter.From(1, 2, 3, 4).Count(even))
+	assert.Equal(t, gs.Some(10), iter.From(1, 2, 3, 4).Reduce(func(a, b int) int { return a + b }))
+	assert.True(t, iter.Empty[int]().Reduce(func(a, b int) int { return a + b }).IsEmpty())
+
+	sum := 0
+	iter.From(1, 2, 3).Foreach(func(v int) { sum += v })
+	assert.Equal(t, 6, sum)
+}
+
+func TestMapFlatMap(t *testing.T) {
+	assert.Equal(t,
+		slices.From("1", "2", "3"),
+		iter.Map(iter.From(1, 2, 3), strconv.Itoa).Slice(),
+	)
+
+	assert.Equal(t,
+		slices.From(1, 1, 2, 2, 3, 3),
+		iter.FlatMap(iter.From(1, 2, 3), func(v int) iter.I[int] {
+			return iter.From(v, v)
+		}).Slice(),
+	)
+
+	assert.Equal(t,
+		slices.From(2, 3),
+		iter.FlatMap(iter.From(1, 2, 3), func(v int) iter.I[int] {
+			if v == 1 {
+				return iter.Empty[int]()
+			}
+			return iter.From(v)
+		}).Slice(),
+	)
+
+	assert.Equal(t,
+		slices.From(4, 8),
+		iter.Collect(iter.From(1, 2, 3, 4), func(v int) (int, bool) {
+			return v * 2, even(v)
+		}).Slice(),
+	)
+}
+
+func TestZipScan(t *testing.T) {
+	assert.Equal(t,
+		slices.From(gs.T2(1, "a"), gs.T2(2, "b")),
+		iter.Zip(iter.From(1, 2, 3), iter.From("a", "b")).Slice(),
+	)
+
+	assert.Equal(t,
+		slices.ScanLeft(slices.From(1, 2, 3), 0, func(a, b int) int { return a + b }),
+		iter.Scan(iter.From(1, 2, 3), 0, func(a, b int) int { return a + b }).Slice(),
+	)
+}
+
+func TestLazy(t *testing.T) {
+	count := 0
+	it := iter.Map(
+		iter.Range(0, 1000000, 1).Filter(func(v int) bool {
+			count++
+			return even(v)
+		}),
+		func(v int) int { return v * 10 },
+	).Take(3)
+
+	assert.Equal(t, 0, count)
+	assert.Equal(t, slices.From(0, 20, 40), it.Slice())
+	assert.Equal(t, 5, count)
+}
+
+var benchS = slices.Range(0, 1000000, 1)
+
+func BenchmarkEagerTake(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		slices.Map(benchS.Filter(even), strconv.Itoa).Take(10)
+	}
+}
+
+func BenchmarkLazyTake(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		iter.Map(iter.FromSlice(benchS).Filter(even), strconv.Itoa).Take(10).Slice()
+	}
+}
+
+func BenchmarkEagerFull(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		slices.Map(benchS.Filter(even), funcs.Self[int]).Reduce(func(a, b int) int { return a + b })
+	}
+}
+
+func BenchmarkLazyFull(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		iter.Map(iter.FromSlice(benchS).Filter(even), funcs.Self[int]).Reduce(func(a, b int) int { return a + b })
+	}
+}
+
+func TestSliding(t *testing.T) {
+	prop := func(s []int, size, step uint8) bool {
+		a, b := int(size%10)+1, int(step%10)+1
+		return assert.Equal(t, slices.S[slices.S[int]](slices.S[int](s).Sliding(a, b)), iter.Sliding(iter.FromSlice(s), a, b).Slice())
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+
+	assert.Equal(t,
+		slices.From(slices.From(0, 1), slices.From(4, 5)),
+		iter.Sliding(iter.Range(0, 1000000000, 1), 2, 4).Take(2).Slice(),
+	)
+	assert.Panics(t, func() { iter.Sliding(iter.From(1), 0, 1) })
+}
+
+func TestGrouped(t *testing.T) {
+	assert.Equal(t,
+		slices.From(slices.From(1, 2, 3), slices.From(4, 5, 6), slices.From(7)),
+		iter.Grouped(iter.Range(1, 8, 1), 3).Slice(),
+	)
+	assert.Equal(t, slices.Empty[slices.S[int]](), iter.Grouped(iter.Empty[int](), 3).Slice())
+}
+
+func TestChunkBy(t *testing.T) {
+	prop := func(s []int) bool {
+		return assert.Equal(t, slices.ChunkBy(s, even), iter.ChunkBy(iter.FromSlice(s), even).Slice())
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+
+	count := 0
+	it := iter.ChunkWhile(iter.Continually(func() int { count++; return count }), func(a, b int) bool {
+		return b%3 != 0
+	})
+	assert.Equal(t, 0, count)
+	assert.Equal(t, slices.From(slices.From(1, 2), slices.From(3, 4, 5)), it.Take(2).Slice())
+}
@@ -0,0 +1,159 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package maps
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/slices"
+)
+
+// ErrCollision represents a key or value is already in a Bi with another value or key.
+var ErrCollision = errors.New("collision")
+
+// Collision is a policy of Bi when a new pair has a key or value already in the Bi.
+type Collision int
+
+const (
+	// Reject rejects the new pair with ErrCollision.
+	Reject Collision = iota
+
+	// Overwrite removes existing pairs having the key or the value, and then adds the new pair.
+	Overwrite
+)
+
+// Bi is a bidirectional map keeping keys and values unique like BiMap in Guava. The zero value is an empty map with Reject policy.
+type Bi[K, V comparable] struct {
+	_      struct{}
+	policy Collision
+	m      M[K, V]
+	inv    M[V, K]
+}
+
+// NewBi returns a Bi with given collision policy and pairs. It returns ErrCollision if pairs collide with Reject policy.
+func NewBi[K, V comparable](policy Collision, pairs ...Pair[K, V]) (*Bi[K, V], error) {
+	ret := &Bi[K, V]{policy: policy}
+	return ret, ret.Add(pairs...)
+}
+
+func (b *Bi[K, V]) init() {
+	if b.m == nil {
+		b.m, b.inv = make(M[K, V]), make(M[V, K])
+	}
+}
+
+// Policy returns collision policy of b.
+func (b *Bi[K, V]) Policy() Collision {
+	return b.policy
+}
+
+// Len returns numbers of elements in b.
+func (b *Bi[K, V]) Len() int {
+	return len(b.m)
+}
+
+// IsEmpty returns true if b has no element.
+func (b *Bi[K, V]) IsEmpty() bool {
+	return len(b.m) <= 0
+}
+
+// Get returns Some with value of given key, or returns None if b does not have the key.
+func (b *Bi[K, V]) Get(key K) gs.Option[V] {
+	if v, ok := b.m[key]; ok {
+		return gs.Some(v)
+	}
+	return gs.None[V]()
+}
+
+// Contain returns true if b has given key x.
+func (b *Bi[K, V]) Contain(x K) bool {
+	return b.m.Contain(x)
+}
+
+// ContainValue returns true if b has given value x.
+func (b *Bi[K, V]) ContainValue(x V) bool {
+	return b.inv.Contain(x)
+}
+
+// Put adds key and value into b. If the key or the value is already in b with another value or key,
+// Put returns ErrCollision with Reject policy, or removes the existing pairs with Overwrite policy.
+func (b *Bi[K, V]) Put(key K, val V) error {
+	b.init()
+
+	oldV, hasKey := b.m[key]
+	oldK, hasVal := b.inv[val]
+	if hasKey && hasVal && oldV == val {
+		return nil
+	}
+
+	if (hasKey || hasVal) && b.policy == Reject {
+		return fmt.Errorf(`%w: %v -> %v`, ErrCollision, key, val)
+	}
+
+	if hasKey {
+		delete(b.inv, oldV)
+	}
+	if hasVal {
+		delete(b.m, oldK)
+	}
+	b.m[key] = val
+	b.inv[val] = key
+	return nil
+}
+
+// Add adds pairs into b. It stops at the first pair failed to add and returns the error.
+func (b *Bi[K, V]) Add(pairs ...Pair[K, V]) error {
+	for _, p := range pairs {
+		if err := b.Put(p.Key, p.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes given keys and their values from b.
+func (b *Bi[K, V]) Remove(keys ...K) *Bi[K, V] {
+	for _, key := range keys {
+		if v, ok := b.m[key]; ok {
+			delete(b.m, key)
+			delete(b.inv, v)
+		}
+	}
+	return b
+}
+
+// Inverse returns a view of b mapping values to keys with the same policy. Changes on the view are reflected in b, and vice versa.
+func (b *Bi[K, V]) Inverse() *Bi[V, K] {
+	b.init()
+	return &Bi[V, K]{policy: b.policy, m: b.inv, inv: b.m}
+}
+
+// Keys returns a slice of all keys.
+func (b *Bi[K, V]) Keys() slices.S[K] {
+	return b.m.Keys()
+}
+
+// Values returns a slice of all values.
+func (b *Bi[K, V]) Values() slices.S[V] {
+	return b.inv.Keys()
+}
+
+// Foreach applies given function op to all elements in b.
+func (b *Bi[K, V]) Foreach(op func(K, V)) {
+	b.m.Foreach(op)
+}
+
+// Slice returns a slice containing key-value pairs of b.
+func (b *Bi[K, V]) Slice() slices.S[Pair[K, V]] {
+	return b.m.Slice()
+}
+
+// M returns a map containing all elements of b.
+func (b *Bi[K, V]) M() M[K, V] {
+	return make(M[K, V], len(b.m)).Merge(b.m)
+}
@@ -0,0 +1,203 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package maps
+
+import (
+	"sync"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/internal/hash"
+)
+
+// shards is numbers of shards in a Concurrent.
+const shards = 32
+
+type shard[K comparable, V any] struct {
+	_ struct{}
+	sync.RWMutex
+	m M[K, V]
+}
+
+// Concurrent is a map safe for concurrent use by multiple goroutines. Keys are split into shards guarded by their own locks.
+// Operations on one key are atomic. The zero value is an empty map ready to use, and a Concurrent must not be copied after first use.
+type Concurrent[K comparable, V any] struct {
+	_      struct{}
+	shards [shards]shard[K, V]
+}
+
+// NewConcurrent returns a Concurrent containing given pairs.
+func NewConcurrent[K comparable, V any](pairs ...Pair[K, V]) *Concurrent[K, V] {
+	return (&Concurrent[K, V]{}).Add(pairs...)
+}
+
+// lookup returns Some with value of given key in m, or returns None if m does not have the key.
+func lookup[K comparable, V any](m M[K, V], key K) gs.Option[V] {
+	if v, ok := m[key]; ok {
+		return gs.Some(v)
+	}
+	return gs.None[V]()
+}
+
+// shard returns the shard of given key.
+func (c *Concurrent[K, V]) shard(key K) *shard[K, V] {
+	return &c.shards[hash.Of(key)%shards]
+}
+
+// read applies given function op to the shard of given key with read lock.
+func (c *Concurrent[K, V]) read(key K, op func(M[K, V])) {
+	s := c.shard(key)
+	s.RLock()
+	defer s.RUnlock()
+	op(s.m)
+}
+
+// write applies given function op to the shard of given key with write lock.
+func (c *Concurrent[K, V]) write(key K, op func(M[K, V])) {
+	s := c.shard(key)
+	s.Lock()
+	defer s.Unlock()
+	if s.m == nil {
+		s.m = make(M[K, V])
+	}
+	op(s.m)
+}
+
+// Len returns numbers of elements in c.
+func (c *Concurrent[K, V]) Len() (ret int) {
+	for i := range c.shards {
+		c.shards[i].RLock()
+		ret += len(c.shards[i].m)
+		c.shards[i].RUnlock()
+	}
+	return
+}
+
+// IsEmpty returns true if c has no element.
+func (c *Concurrent[K, V]) IsEmpty() bool {
+	return c.Len() <= 0
+}
+
+// Get returns Some with value of given key, or returns None if c does not have the key.
+func (c *Concurrent[K, V]) Get(key K) (ret gs.Option[V]) {
+	c.read(key, func(m M[K, V]) {
+		ret = lookup(m, key)
+	})
+	return
+}
+
+// Contain returns true if c has given key x.
+func (c *Concurrent[K, V]) Contain(x K) (ok bool) {
+	c.read(x, func(m M[K, V]) {
+		ok = m.Contain(x)
+	})
+	return
+}
+
+// Put adds key and value into c.
+func (c *Concurrent[K, V]) Put(key K, val V) *Concurrent[K, V] {
+	c.write(key, func(m M[K, V]) {
+		m[key] = val
+	})
+	return c
+}
+
+// Add adds pairs into c.
+func (c *Concurrent[K, V]) Add(pairs ...Pair[K, V]) *Concurrent[K, V] {
+	for _, p := range pairs {
+		c.Put(p.Key, p.Value)
+	}
+	return c
+}
+
+// Remove removes given keys from c.
+func (c *Concurrent[K, V]) Remove(keys ...K) *Concurrent[K, V] {
+	for _, key := range keys {
+		c.write(key, func(m M[K, V]) {
+			delete(m, key)
+		})
+	}
+	return c
+}
+
+// PutIfAbsent adds key and value into c if c does not have the key.
+// It returns Some with the existing value, or returns None if the value is added.
+func (c *Concurrent[K, V]) PutIfAbsent(key K, val V) (ret gs.Option[V]) {
+	c.write(key, func(m M[K, V]) {
+		if ret = lookup(m, key); ret.IsEmpty() {
+			m[key] = val
+		}
+	})
+	return
+}
+
+// GetOrElseUpdate returns value of given key if c has the key.
+// Otherwise, it adds the result of given function op with the key into c, and returns the result.
+// op is called at most once and other operations on the same shard wait until op returns.
+func (c *Concurrent[K, V]) GetOrElseUpdate(key K, op funcs.Unit[V]) V {
+	if v := c.Get(key); v.IsDefined() {
+		return v.Get()
+	}
+
+	return c.Compute(key, func(old gs.Option[V]) gs.Option[V] {
+		if old.IsDefined() {
+			return old
+		}
+		return gs.Some(op())
+	}).Get()
+}
+
+// Compute atomically replaces value of given key with the result of given function op, and returns the result.
+// op takes Some with current value, or None if c does not have the key. The key is removed if op returns None.
+// op must not access c, because the shard of the key is locked while op runs.
+func (c *Concurrent[K, V]) Compute(key K, op func(gs.Option[V]) gs.Option[V]) (ret gs.Option[V]) {
+	c.write(key, func(m M[K, V]) {
+		ret = op(lookup(m, key))
+		if ret.IsDefined() {
+			m[key] = ret.Get()
+		} else {
+			delete(m, key)
+		}
+	})
+	return
+}
+
+// RemoveIf removes all elements satisfying given function p from c. Each shard is checked atomically.
+// p must not access c.
+func (c *Concurrent[K, V]) RemoveIf(p func(K, V) bool) *Concurrent[K, V] {
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.Lock()
+		for k, v := range s.m {
+			if p(k, v) {
+				delete(s.m, k)
+			}
+		}
+		s.Unlock()
+	}
+	return c
+}
+
+// Snapshot returns a map containing all elements of c at a point in time.
+func (c *Concurrent[K, V]) Snapshot() M[K, V] {
+	for i := range c.shards {
+		c.shards[i].RLock()
+	}
+
+	ret := make(M[K, V])
+	for i := range c.shards {
+		ret.Merge(c.shards[i].m)
+		c.shards[i].RUnlock()
+	}
+	return ret
+}
+
+// Foreach applies given function op to all elements of a snapshot of c. op can access c.
+func (c *Concurrent[K, V]) Foreach(op func(K, V)) {
+	for k, v := range c.Snapshot() {
+		op(k, v)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package maps
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/dairaga/gs/slices"
+)
+
+// marshalKey converts given key k to a JSON object key like encoding/json does for map.
+func marshalKey[K comparable](k K) (string, error) {
+	v := reflect.ValueOf(k)
+	if !v.IsValid() {
+		return "", fmt.Errorf(`maps: unsupported nil key`)
+	}
+
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+
+	if tm, ok := interface{}(k).(encoding.TextMarshaler); ok {
+		data, err := tm.MarshalText()
+		return string(data), err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", &json.UnsupportedTypeError{Type: v.Type()}
+}
+
+// unmarshalKey converts given JSON object key s to K like encoding/json does for map.
+func unmarshalKey[K comparable](s string) (k K, err error) {
+	if tu, ok := interface{}(&k).(encoding.TextUnmarshaler); ok {
+		err = tu.UnmarshalText([]byte(s))
+		return
+	}
+
+	v := reflect.ValueOf(&k).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, v.Type().Bits()); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, v.Type().Bits()); err == nil {
+			v.SetUint(n)
+		}
+	default:
+		err = &json.UnsupportedTypeError{Type: v.Type()}
+	}
+	return
+}
+
+// marshalPairs encodes given pairs to a JSON object keeping their order.
+func marshalPairs[K comparable, V any](pairs slices.S[Pair[K, V]]) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := range pairs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := marshalKey(pairs[i].Key)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		buf.WriteByte(':')
+
+		if data, err = json.Marshal(pairs[i].Value); err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// isNull returns true if given JSON data is null.
+func isNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte(`null`))
+}
+
+// unmarshalPairs decodes pairs from a JSON object keeping their order in data.
+func unmarshalPairs[K comparable, V any](data []byte) (slices.S[Pair[K, V]], error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf(`maps: expect JSON object but %v`, tok)
+	}
+
+	ret := slices.Empty[Pair[K, V]]()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		k, err := unmarshalKey[K](tok.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		ret = append(ret, P(k, v))
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
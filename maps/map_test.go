@@ -6,8 +6,14 @@
 package maps_test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/quick"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
@@ -381,6 +387,17 @@ func TestMaxBy(t *testing.T) {
 		))
 }
 
+func TestMaxByFunc(t *testing.T) {
+	key := func(k int, _ string) int { return k }
+
+	assert.Equal(
+		t,
+		gs.Some(maps.P(1, "1")),
+		maps.MaxByFunc(testM, key, funcs.Reverse(funcs.Order[int])),
+	)
+	assert.False(t, maps.MaxByFunc(maps.From[int, string](), key, funcs.Order[int]).IsDefined())
+}
+
 func TestMinBy(t *testing.T) {
 	assert.False(
 		t,
@@ -397,3 +414,494 @@ func TestMinBy(t *testing.T) {
 			func(key int, _ string) int { return key },
 		))
 }
+
+func TestMinByFunc(t *testing.T) {
+	key := func(_ int, v string) string { return v }
+
+	assert.Equal(
+		t,
+		gs.Some(maps.P(9, "9")),
+		maps.MinByFunc(testM, key, funcs.Reverse(funcs.Order[string])),
+	)
+	assert.False(t, maps.MinByFunc(maps.From[int, string](), key, funcs.Order[string]).IsDefined())
+}
+
+func TestOrdered(t *testing.T) {
+	o := maps.NewOrdered(maps.P("c", 3), maps.P("a", 1), maps.P("b", 2))
+	assert.Equal(t, slices.From("c", "a", "b"), o.Keys())
+	assert.Equal(t, slices.From(3, 1, 2), o.Values())
+	assert.Equal(t, 3, o.Len())
+	assert.Equal(t, gs.Some(1), o.Get("a"))
+	assert.True(t, o.Get("z").IsEmpty())
+
+	o.Put("a", 10).Put("d", 4)
+	assert.Equal(t, slices.From(maps.P("c", 3), maps.P("a", 10), maps.P("b", 2), maps.P("d", 4)), o.Slice())
+
+	o.Remove("c", "b", "z")
+	assert.Equal(t, slices.From("a", "d"), o.Keys())
+	o.Remove("d")
+	assert.Equal(t, slices.From("a"), o.Keys())
+	o.Remove("a")
+	assert.True(t, o.IsEmpty())
+	o.Put("e", 5)
+	assert.Equal(t, slices.From("e"), o.Keys())
+
+	var zero maps.Ordered[int, int]
+	assert.True(t, zero.IsEmpty())
+	zero.Put(1, 1)
+	assert.Equal(t, "Ordered(1 -> 1)", zero.String())
+}
+
+func TestOrderedMethods(t *testing.T) {
+	o := maps.NewOrdered(maps.P(5, "5"), maps.P(2, "2"), maps.P(3, "3"), maps.P(4, "4"))
+	even := func(k int, _ string) bool { return k%2 == 0 }
+
+	assert.Equal(t, gs.Some(maps.P(2, "2")), o.Find(even))
+	assert.True(t, o.Exists(even))
+	assert.False(t, o.Forall(even))
+	assert.Equal(t, 2, o.Count(even))
+	assert.Equal(t, slices.From(2, 4), o.Filter(even).Keys())
+	assert.Equal(t, slices.From(5, 3), o.FilterNot(even).Keys())
+
+	a, b := o.Partition(even)
+	assert.Equal(t, slices.From(5, 3), a.Keys())
+	assert.Equal(t, slices.From(2, 4), b.Keys())
+
+	keys := slices.Empty[int]()
+	o.Foreach(func(k int, _ string) { keys = append(keys, k) })
+	assert.Equal(t, o.Keys(), keys)
+
+	c := o.Clone().Put(1, "1")
+	assert.Equal(t, 4, o.Len())
+	assert.Equal(t, slices.From(5, 2, 3, 4, 1), c.Keys())
+
+	assert.Equal(t, map[int]string{5: "5", 2: "2", 3: "3", 4: "4"}, map[int]string(o.M()))
+	assert.Equal(t, slices.From(2, 3, 4, 5), o.M().Ordered(funcs.Order[int]).Keys())
+	assert.Equal(t, "Ordered(5 -> 5, 2 -> 2, 3 -> 3, 4 -> 4)", o.String())
+}
+
+func TestOrderedFunctions(t *testing.T) {
+	o := maps.NewOrdered(maps.P(3, "3"), maps.P(1, "1"), maps.P(2, "2"))
+
+	assert.Equal(t, "312", maps.FoldOrdered(o, "", func(z string, _ int, v string) string { return z + v }))
+
+	m := maps.MapOrdered(o, func(k int, v string) (string, int) { return v, k * 10 })
+	assert.Equal(t, slices.From(maps.P("3", 30), maps.P("1", 10), maps.P("2", 20)), m.Slice())
+
+	f := maps.FlatMapOrdered(o, func(k int, v string) *maps.Ordered[int, string] {
+		return maps.NewOrdered(maps.P(k, v), maps.P(k*10, v+"0"))
+	})
+	assert.Equal(t, slices.From(3, 30, 1, 10, 2, 20), f.Keys())
+
+	g := maps.GroupByOrdered(o, func(k int, _ string) bool { return k%2 == 0 })
+	assert.Equal(t, slices.From(false, true), g.Keys())
+	assert.Equal(t, slices.From(3, 1), g.Get(false).Get().Keys())
+	assert.Equal(t, slices.From(2), g.Get(true).Get().Keys())
+}
+
+type textKey struct {
+	a, b int
+}
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d-%d", k.a, k.b)), nil
+}
+
+func (k *textKey) UnmarshalText(data []byte) error {
+	_, err := fmt.Sscanf(string(data), "%d-%d", &k.a, &k.b)
+	return err
+}
+
+func TestOrderedJSON(t *testing.T) {
+	o := maps.NewOrdered(maps.P("z", 1), maps.P("a", 2), maps.P("m", 3))
+	data, err := json.Marshal(o)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"z":1,"a":2,"m":3}`, string(data))
+
+	var dst maps.Ordered[string, int]
+	assert.Nil(t, json.Unmarshal([]byte(`{"b":1,"a":2,"c":3}`), &dst))
+	assert.Equal(t, slices.From("b", "a", "c"), dst.Keys())
+	assert.Equal(t, slices.From(1, 2, 3), dst.Values())
+
+	ints := maps.NewOrdered(maps.P(10, "a"), maps.P(-1, "b"))
+	data, err = json.Marshal(ints)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"10":"a","-1":"b"}`, string(data))
+
+	var ints2 maps.Ordered[int8, string]
+	assert.Nil(t, json.Unmarshal(data, &ints2))
+	assert.Equal(t, slices.From[int8](10, -1), ints2.Keys())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"300":"a"}`), &ints2))
+
+	texts := maps.NewOrdered(maps.P(textKey{2, 1}, true), maps.P(textKey{1, 2}, false))
+	data, err = json.Marshal(texts)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"2-1":true,"1-2":false}`, string(data))
+
+	var texts2 maps.Ordered[textKey, bool]
+	assert.Nil(t, json.Unmarshal(data, &texts2))
+	assert.Equal(t, texts.Slice(), texts2.Slice())
+
+	_, err = json.Marshal(maps.NewOrdered(maps.P(1.5, 1)))
+	assert.NotNil(t, err)
+	assert.NotNil(t, json.Unmarshal([]byte(`[1]`), &dst))
+
+	type st struct {
+		O *maps.Ordered[string, int] `json:"o"`
+	}
+	data, err = json.Marshal(st{O: maps.NewOrdered(maps.P("y", 1), maps.P("x", 2))})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"o":{"y":1,"x":2}}`, string(data))
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), &dst))
+	assert.Equal(t, slices.From("b", "a", "c"), dst.Keys())
+
+	var vst struct {
+		O maps.Ordered[string, int] `json:"o"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"o":null}`), &vst))
+	assert.True(t, vst.O.IsEmpty())
+}
+
+func TestSorted(t *testing.T) {
+	s := maps.NewSorted(funcs.Order[int], maps.P(5, "5"), maps.P(1, "1"), maps.P(3, "3"))
+	assert.Equal(t, slices.From(1, 3, 5), s.Keys())
+	assert.Equal(t, slices.From("1", "3", "5"), s.Values())
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, gs.Some("3"), s.Get(3))
+	assert.True(t, s.Get(2).IsEmpty())
+	assert.True(t, s.Contain(5))
+
+	s.Put(3, "three").Put(7, "7")
+	assert.Equal(t, "Sorted(1 -> 1, 3 -> three, 5 -> 5, 7 -> 7)", s.String())
+
+	assert.Equal(t, gs.Some(maps.P(1, "1")), s.Head())
+	assert.Equal(t, gs.Some(maps.P(7, "7")), s.Last())
+
+	assert.Equal(t, gs.Some(maps.P(3, "three")), s.Floor(4))
+	assert.Equal(t, gs.Some(maps.P(5, "5")), s.Floor(5))
+	assert.True(t, s.Floor(0).IsEmpty())
+	assert.Equal(t, gs.Some(maps.P(5, "5")), s.Ceiling(4))
+	assert.Equal(t, gs.Some(maps.P(1, "1")), s.Ceiling(0))
+	assert.True(t, s.Ceiling(8).IsEmpty())
+
+	assert.Equal(t, slices.From(3, 5), s.Range(2, 7).Keys())
+	assert.Equal(t, slices.From(3, 5, 7), s.Range(3, 100).Keys())
+	assert.True(t, s.Range(8, 10).IsEmpty())
+
+	assert.Equal(t, gs.Some(maps.P(5, "5")), s.Find(func(k int, _ string) bool { return k > 3 }))
+	assert.Equal(t, 16, maps.FoldSorted(s, 0, func(z, k int, _ string) int { return z + k }))
+
+	s.Remove(3, 4)
+	assert.Equal(t, slices.From(1, 5, 7), s.Keys())
+	s.Remove(1, 5, 7)
+	assert.True(t, s.IsEmpty())
+	assert.True(t, s.Head().IsEmpty())
+	assert.True(t, s.Last().IsEmpty())
+
+	desc := maps.NewSorted(funcs.Reverse(funcs.Order[string]), maps.P("a", 1), maps.P("c", 3), maps.P("b", 2))
+	assert.Equal(t, slices.From("c", "b", "a"), desc.Keys())
+	assert.Equal(t, slices.From(3, 2, 1), desc.M().Sorted(funcs.Reverse(funcs.Order[string])).Values())
+
+	c := desc.Clone().Remove("a")
+	assert.Equal(t, 3, desc.Len())
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestSortedRandom(t *testing.T) {
+	prop := func(puts []int8, removes []int8) bool {
+		s := maps.NewSorted[int8, int](funcs.Order[int8])
+		m := make(map[int8]int)
+
+		for i, k := range puts {
+			s.Put(k, i)
+			m[k] = i
+		}
+		for _, k := range removes {
+			s.Remove(k)
+			delete(m, k)
+		}
+
+		keys := maps.M[int8, int](m).Keys().Sort(funcs.Order[int8])
+		return s.Len() == len(m) &&
+			slices.Equal(keys, s.Keys()) &&
+			assert.Equal(t, m, map[int8]int(s.M()))
+	}
+	assert.Nil(t, quick.Check(prop, &quick.Config{MaxCount: 500}))
+
+	s := maps.NewSorted[int, int](funcs.Order[int])
+	for i := 0; i < 10000; i++ {
+		s.Put(i, i)
+	}
+	for i := 0; i < 10000; i += 2 {
+		s.Remove(i)
+	}
+	assert.Equal(t, 5000, s.Len())
+	assert.Equal(t, slices.Range(1, 10000, 2), s.Keys())
+	assert.Equal(t, gs.Some(maps.P(4999, 4999)), s.Floor(5000))
+}
+
+func TestSortedJSON(t *testing.T) {
+	s := maps.NewSorted(funcs.Order[string], maps.P("b", 2), maps.P("a", 1))
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1,"b":2}`, string(data))
+
+	dst := maps.NewSorted[string, int](funcs.Reverse(funcs.Order[string]))
+	assert.Nil(t, json.Unmarshal(data, dst))
+	assert.Equal(t, slices.From("b", "a"), dst.Keys())
+
+	var zero maps.Sorted[string, int]
+	assert.True(t, errors.Is(json.Unmarshal(data, &zero), maps.ErrNoOrdering))
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), dst))
+	assert.Equal(t, slices.From("b", "a"), dst.Keys())
+	assert.Nil(t, json.Unmarshal([]byte(` null `), &zero))
+	assert.True(t, zero.IsEmpty())
+}
+
+func TestConcurrent(t *testing.T) {
+	var zero maps.Concurrent[int, string]
+	assert.True(t, zero.IsEmpty())
+	assert.True(t, zero.Get(1).IsEmpty())
+	assert.Equal(t, maps.M[int, string]{}, zero.Snapshot())
+
+	c := maps.NewConcurrent(maps.P(1, "1"), maps.P(2, "2"))
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, gs.Some("1"), c.Get(1))
+	assert.True(t, c.Contain(2))
+	assert.False(t, c.Contain(3))
+
+	c.Put(3, "3").Add(maps.P(4, "4"), maps.P(5, "5")).Remove(5, 6)
+	assert.Equal(t, testM.Filter(func(k int, _ string) bool { return k < 5 }), c.Snapshot())
+
+	assert.Equal(t, gs.Some("1"), c.PutIfAbsent(1, "one"))
+	assert.True(t, c.PutIfAbsent(6, "6").IsEmpty())
+	assert.Equal(t, gs.Some("6"), c.Get(6))
+
+	calls := 0
+	op := func() string { calls++; return "7" }
+	assert.Equal(t, "7", c.GetOrElseUpdate(7, op))
+	assert.Equal(t, "7", c.GetOrElseUpdate(7, op))
+	assert.Equal(t, 1, calls)
+
+	concat := func(old gs.Option[string]) gs.Option[string] {
+		return gs.Some(old.GetOrElse("") + "!")
+	}
+	assert.Equal(t, gs.Some("1!"), c.Compute(1, concat))
+	assert.Equal(t, gs.Some("!"), c.Compute(8, concat))
+	assert.True(t, c.Compute(8, func(gs.Option[string]) gs.Option[string] { return gs.None[string]() }).IsEmpty())
+	assert.False(t, c.Contain(8))
+
+	c.RemoveIf(func(k int, _ string) bool { return k > 3 })
+	assert.Equal(t, maps.From(maps.P(1, "1!"), maps.P(2, "2"), maps.P(3, "3")), c.Snapshot())
+
+	keys := slices.Empty[int]()
+	c.Foreach(func(k int, _ string) {
+		keys = append(keys, k)
+		c.Remove(k)
+	})
+	assert.ElementsMatch(t, []int{1, 2, 3}, keys)
+	assert.True(t, c.IsEmpty())
+}
+
+func TestConcurrentRace(t *testing.T) {
+	var c maps.Concurrent[int, int]
+	var calls int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Compute(j%100, func(old gs.Option[int]) gs.Option[int] {
+					return gs.Some(old.GetOrElse(0) + 1)
+				})
+				c.GetOrElseUpdate(1000+j%10, func() int {
+					atomic.AddInt32(&calls, 1)
+					return j
+				})
+				c.PutIfAbsent(2000+i, j)
+				c.Get(j)
+				if j%100 == 0 {
+					c.Snapshot()
+					c.RemoveIf(func(k, _ int) bool { return k >= 3000 })
+				}
+				c.Put(3000+j, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(10), calls)
+	for j := 0; j < 100; j++ {
+		assert.Equal(t, gs.Some(160), c.Get(j))
+	}
+	for i := 0; i < 16; i++ {
+		assert.True(t, c.Contain(2000+i))
+	}
+}
+
+func benchmarkMixed(b *testing.B, get func(int) gs.Option[int], put func(int, int)) {
+	for i := 0; i < 1000; i++ {
+		put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10 == 0 {
+				put(i%1000, i)
+			} else {
+				get(i % 1000)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrent(b *testing.B) {
+	var c maps.Concurrent[int, int]
+	benchmarkMixed(b,
+		c.Get,
+		func(k, v int) { c.Put(k, v) },
+	)
+}
+
+func BenchmarkMutexMap(b *testing.B) {
+	var mu sync.RWMutex
+	m := make(maps.M[int, int])
+	benchmarkMixed(b,
+		func(k int) gs.Option[int] {
+			mu.RLock()
+			defer mu.RUnlock()
+			if v, ok := m[k]; ok {
+				return gs.Some(v)
+			}
+			return gs.None[int]()
+		},
+		func(k, v int) {
+			mu.Lock()
+			defer mu.Unlock()
+			m.Put(k, v)
+		},
+	)
+}
+
+func TestMulti(t *testing.T) {
+	var zero maps.Multi[string, int]
+	assert.True(t, zero.IsEmpty())
+	assert.Equal(t, slices.Empty[int](), zero.Get("a"))
+	assert.True(t, maps.RemoveValue(&zero, "a", 1).Remove("a").IsEmpty())
+
+	m := maps.NewMulti(maps.P("a", 1), maps.P("b", 2), maps.P("a", 3))
+	m.Put("a", 1).Put("c", 4)
+	assert.Equal(t, 5, m.Len())
+	assert.Equal(t, slices.From(1, 3, 1), m.Get("a"))
+	assert.Equal(t, slices.From(2), m.Get("b"))
+	assert.True(t, m.Contain("c"))
+	assert.False(t, m.Contain("d"))
+	assert.True(t, maps.ContainValue(m, "a", 3))
+	assert.False(t, maps.ContainValue(m, "b", 3))
+	assert.True(t, m.ContainValueFunc("a", func(v int) bool { return v > 2 }))
+	assert.False(t, m.ContainValueFunc("b", func(v int) bool { return v > 2 }))
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, m.Keys())
+
+	vs := m.Get("a")
+	vs[0] = 100
+	assert.Equal(t, slices.From(1, 3, 1), m.Get("a"))
+
+	pairs := m.Flatten()
+	assert.ElementsMatch(t, []maps.Pair[string, int]{maps.P("a", 1), maps.P("a", 3), maps.P("a", 1), maps.P("b", 2), maps.P("c", 4)}, pairs)
+	assert.Equal(t, slices.From(1, 3, 1), slices.Map(pairs.Filter(func(p maps.Pair[string, int]) bool { return p.Key == "a" }), func(p maps.Pair[string, int]) int { return p.Value }))
+
+	maps.RemoveValue(m, "a", 1)
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, slices.From(3), m.Get("a"))
+	m.RemoveValueFunc("a", func(v int) bool { return v == 3 }).RemoveValueFunc("b", func(v int) bool { return v > 100 })
+	assert.False(t, m.Contain("a"))
+	assert.Equal(t, 2, m.Len())
+
+	sum := 0
+	m.Foreach(func(_ string, v int) { sum += v })
+	assert.Equal(t, 6, sum)
+
+	m.Remove("b", "x")
+	assert.Equal(t, maps.M[string, slices.S[int]]{"c": slices.From(4)}, m.M())
+	assert.Equal(t, 1, m.Len())
+
+	groups := slices.GroupBy(slices.From(1, 2, 3, 4, 5), func(x int) bool { return x&1 == 0 })
+	assert.Equal(t, maps.M[bool, slices.S[int]](groups), maps.NewMulti(maps.P(false, 1), maps.P(true, 2), maps.P(false, 3), maps.P(true, 4), maps.P(false, 5)).M())
+
+	fm := maps.NewMulti(maps.P("a", []int{1}), maps.P("a", []int{2, 3}))
+	assert.True(t, fm.ContainValueFunc("a", func(v []int) bool { return len(v) == 2 }))
+	fm.RemoveValueFunc("a", func(v []int) bool { return len(v) == 1 })
+	assert.Equal(t, slices.From([]int{2, 3}), fm.Get("a"))
+	assert.Equal(t, 1, fm.Len())
+}
+
+func TestBi(t *testing.T) {
+	var zero maps.Bi[string, int]
+	assert.Equal(t, maps.Reject, zero.Policy())
+	assert.True(t, zero.IsEmpty())
+	assert.NoError(t, zero.Put("a", 1))
+	assert.Equal(t, gs.Some("a"), zero.Inverse().Get(1))
+
+	b, err := maps.NewBi(maps.Reject, maps.P("a", 1), maps.P("b", 2))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, b.Len())
+	assert.Equal(t, gs.Some(1), b.Get("a"))
+	assert.True(t, b.Get("c").IsEmpty())
+	assert.True(t, b.Contain("b"))
+	assert.True(t, b.ContainValue(2))
+	assert.False(t, b.ContainValue(3))
+
+	assert.NoError(t, b.Put("a", 1))
+	err = b.Put("a", 3)
+	assert.True(t, errors.Is(err, maps.ErrCollision))
+	assert.Equal(t, "collision: a -> 3", err.Error())
+	assert.True(t, errors.Is(b.Put("c", 2), maps.ErrCollision))
+	assert.True(t, errors.Is(b.Add(maps.P("c", 3), maps.P("d", 1), maps.P("e", 5)), maps.ErrCollision))
+	assert.Equal(t, maps.From(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3)), b.M())
+
+	_, err = maps.NewBi(maps.Reject, maps.P("a", 1), maps.P("b", 1))
+	assert.True(t, errors.Is(err, maps.ErrCollision))
+
+	inv := b.Inverse()
+	assert.Equal(t, maps.Reject, inv.Policy())
+	assert.Equal(t, maps.From(maps.P(1, "a"), maps.P(2, "b"), maps.P(3, "c")), inv.M())
+	assert.NoError(t, inv.Put(4, "d"))
+	assert.Equal(t, gs.Some(4), b.Get("d"))
+	b.Remove("a", "x")
+	assert.False(t, inv.Contain(1))
+	assert.Equal(t, maps.From(maps.P("b", 2), maps.P("c", 3), maps.P("d", 4)), inv.Inverse().M())
+
+	o, err := maps.NewBi(maps.Overwrite, maps.P("a", 1), maps.P("b", 2), maps.P("c", 3))
+	assert.NoError(t, err)
+	assert.NoError(t, o.Put("a", 2))
+	assert.Equal(t, maps.From(maps.P("a", 2), maps.P("c", 3)), o.M())
+	assert.NoError(t, o.Put("d", 3))
+	assert.Equal(t, maps.From(maps.P("a", 2), maps.P("d", 3)), o.M())
+	assert.NoError(t, o.Inverse().Put(2, "e"))
+	assert.Equal(t, maps.From(maps.P("e", 2), maps.P("d", 3)), o.M())
+	assert.Equal(t, maps.From(maps.P(2, "e"), maps.P(3, "d")), o.Inverse().M())
+
+	assert.ElementsMatch(t, []string{"d", "e"}, o.Keys())
+	assert.ElementsMatch(t, []int{2, 3}, o.Values())
+	assert.ElementsMatch(t, o.M().Slice(), o.Slice())
+	sum := 0
+	o.Foreach(func(_ string, v int) { sum += v })
+	assert.Equal(t, 5, sum)
+
+	bijective := func(pairs map[uint8]uint8, extra []uint8) bool {
+		b, _ := maps.NewBi[uint8, uint8](maps.Overwrite, maps.M[uint8, uint8](pairs).Slice()...)
+		for i := 0; i+1 < len(extra); i += 2 {
+			_ = b.Put(extra[i], extra[i+1])
+		}
+		inv := b.Inverse()
+		return b.Len() == inv.Len() && b.M().Forall(func(k, v uint8) bool { return inv.Get(v).GetOrElse(^k) == k })
+	}
+	assert.NoError(t, quick.Check(bijective, nil))
+}
@@ -39,15 +39,9 @@ func From[K comparable, V any](a ...Pair[K, V]) (ret M[K, V]) {
 	return ret
 }
 
-// Zip combines given two slices into a map.
-func Zip[K comparable, V any](a slices.S[K], b slices.S[V]) (ret M[K, V]) {
-	size := funcs.Min(len(a), len(b))
-	ret = make(M[K, V], size)
-
-	for i := 0; i < size; i++ {
-		ret[a[i]] = b[i]
-	}
-	return ret
+// Zip combines given two slices into a map. Extra elements of the longer one are ignored.
+func Zip[K comparable, V any](a slices.S[K], b slices.S[V]) M[K, V] {
+	return From(slices.ZipWith(a, b, P[K, V])...)
 }
 
 // -----------------------------------------------------------------------------
@@ -214,6 +208,17 @@ func MaxBy[K comparable, V any, B constraints.Ordered](m M[K, V], op func(K, V)
 	)
 }
 
+// MaxByFunc returns a maximum pair of key and value according to results of given function key compared with given cmp.
+func MaxByFunc[K comparable, V, B any](m M[K, V], key func(K, V) B, cmp funcs.Ordering[B, B]) gs.Option[Pair[K, V]] {
+	return slices.MaxByFunc(
+		m.Slice(),
+		func(pair Pair[K, V]) B {
+			return key(pair.Key, pair.Value)
+		},
+		cmp,
+	)
+}
+
 // MinBy returns a minimum pair of key and value according to result of ordering function op.
 func MinBy[K comparable, V any, B constraints.Ordered](m M[K, V], op func(K, V) B) gs.Option[Pair[K, V]] {
 	return slices.MinBy(
@@ -223,3 +228,14 @@ func MinBy[K comparable, V any, B constraints.Ordered](m M[K, V], op func(K, V)
 		},
 	)
 }
+
+// MinByFunc returns a minimum pair of key and value according to results of given function key compared with given cmp.
+func MinByFunc[K comparable, V, B any](m M[K, V], key func(K, V) B, cmp funcs.Ordering[B, B]) gs.Option[Pair[K, V]] {
+	return slices.MinByFunc(
+		m.Slice(),
+		func(pair Pair[K, V]) B {
+			return key(pair.Key, pair.Value)
+		},
+		cmp,
+	)
+}
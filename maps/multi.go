@@ -0,0 +1,142 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package maps
+
+import (
+	"github.com/dairaga/gs/slices"
+)
+
+// Multi is a map keeping multiple values per key like MultiDict in Scala.
+// Values of a key are kept in insertion order and may be duplicated. The zero value is an empty map ready to use.
+// Functions ContainValue and RemoveValue compare values with == if V is comparable.
+type Multi[K comparable, V any] struct {
+	_    struct{}
+	m    M[K, slices.S[V]]
+	size int
+}
+
+// NewMulti returns a Multi containing given pairs.
+func NewMulti[K comparable, V any](pairs ...Pair[K, V]) *Multi[K, V] {
+	return new(Multi[K, V]).Add(pairs...)
+}
+
+// Len returns numbers of key-value pairs in m.
+func (m *Multi[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if m has no element.
+func (m *Multi[K, V]) IsEmpty() bool {
+	return m.size <= 0
+}
+
+// Get returns a new slice containing values of given key in insertion order, or returns an empty slice if m does not have the key.
+func (m *Multi[K, V]) Get(key K) slices.S[V] {
+	if vs, ok := m.m[key]; ok {
+		return vs.Clone()
+	}
+	return slices.Empty[V]()
+}
+
+// Contain returns true if m has given key x.
+func (m *Multi[K, V]) Contain(x K) bool {
+	return m.m.Contain(x)
+}
+
+// ContainValueFunc returns true if m has a value satisfying given function p for given key.
+func (m *Multi[K, V]) ContainValueFunc(key K, p func(V) bool) bool {
+	return m.m[key].IndexWhere(p) >= 0
+}
+
+// Put appends given value to values of given key.
+func (m *Multi[K, V]) Put(key K, val V) *Multi[K, V] {
+	if m.m == nil {
+		m.m = make(M[K, slices.S[V]])
+	}
+	m.m[key] = append(m.m[key], val)
+	m.size++
+	return m
+}
+
+// Add adds pairs into m.
+func (m *Multi[K, V]) Add(pairs ...Pair[K, V]) *Multi[K, V] {
+	for _, p := range pairs {
+		m.Put(p.Key, p.Value)
+	}
+	return m
+}
+
+// Remove removes given keys and all their values from m.
+func (m *Multi[K, V]) Remove(keys ...K) *Multi[K, V] {
+	for _, key := range keys {
+		m.size -= len(m.m[key])
+		delete(m.m, key)
+	}
+	return m
+}
+
+// RemoveValueFunc removes all values satisfying given function p from values of given key. The key is removed if it has no value left.
+func (m *Multi[K, V]) RemoveValueFunc(key K, p func(V) bool) *Multi[K, V] {
+	vs, ok := m.m[key]
+	if !ok {
+		return m
+	}
+
+	rest := vs.FilterNot(p)
+	m.size -= len(vs) - len(rest)
+	if len(rest) <= 0 {
+		delete(m.m, key)
+	} else {
+		m.m[key] = rest
+	}
+	return m
+}
+
+// Keys returns a slice of all keys.
+func (m *Multi[K, V]) Keys() slices.S[K] {
+	return m.m.Keys()
+}
+
+// Foreach applies given function op to all key-value pairs in m.
+func (m *Multi[K, V]) Foreach(op func(K, V)) {
+	for k, vs := range m.m {
+		for _, v := range vs {
+			op(k, v)
+		}
+	}
+}
+
+// Flatten returns a slice containing all key-value pairs in m. Pairs of a key are in insertion order.
+func (m *Multi[K, V]) Flatten() slices.S[Pair[K, V]] {
+	ret := make(slices.S[Pair[K, V]], 0, m.size)
+	m.Foreach(func(k K, v V) {
+		ret = append(ret, P(k, v))
+	})
+	return ret
+}
+
+// M returns a map containing copies of values of all keys in m.
+func (m *Multi[K, V]) M() M[K, slices.S[V]] {
+	ret := make(M[K, slices.S[V]], len(m.m))
+	for k, vs := range m.m {
+		ret[k] = vs.Clone()
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// ContainValue returns true if given Multi m has given value v for given key.
+func ContainValue[K, V comparable](m *Multi[K, V], key K, v V) bool {
+	return slices.Contain(m.m[key], v)
+}
+
+// RemoveValue removes all values equal to given v from values of given key in given Multi m. The key is removed if it has no value left.
+func RemoveValue[K, V comparable](m *Multi[K, V], key K, v V) *Multi[K, V] {
+	return m.RemoveValueFunc(key, func(x V) bool { return x == v })
+}
@@ -0,0 +1,294 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package maps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/slices"
+)
+
+type entry[K comparable, V any] struct {
+	_     struct{}
+	key   K
+	value V
+	prev  *entry[K, V]
+	next  *entry[K, V]
+}
+
+// Ordered is a map keeping insertion order of keys like LinkedHashMap in Scala.
+// Updating value of an existing key does not change its position. The zero value is an empty map ready to use.
+type Ordered[K comparable, V any] struct {
+	_    struct{}
+	m    map[K]*entry[K, V]
+	head *entry[K, V]
+	tail *entry[K, V]
+}
+
+// NewOrdered returns an Ordered containing given pairs in order.
+func NewOrdered[K comparable, V any](pairs ...Pair[K, V]) *Ordered[K, V] {
+	return new(Ordered[K, V]).Add(pairs...)
+}
+
+// Ordered returns an Ordered containing elements of m in order of keys sorted with given cmp.
+func (m M[K, V]) Ordered(cmp funcs.Ordering[K, K]) *Ordered[K, V] {
+	return NewOrdered(m.Slice().Sort(func(a, b Pair[K, V]) int {
+		return cmp(a.Key, b.Key)
+	})...)
+}
+
+// Len returns numbers of elements in o.
+func (o *Ordered[K, V]) Len() int {
+	return len(o.m)
+}
+
+// IsEmpty returns true if o has no element.
+func (o *Ordered[K, V]) IsEmpty() bool {
+	return len(o.m) <= 0
+}
+
+// Get returns Some with value of given key, or returns None if o does not have the key.
+func (o *Ordered[K, V]) Get(key K) gs.Option[V] {
+	if e, ok := o.m[key]; ok {
+		return gs.Some(e.value)
+	}
+	return gs.None[V]()
+}
+
+// Contain returns true if o has given key x.
+func (o *Ordered[K, V]) Contain(x K) (ok bool) {
+	_, ok = o.m[x]
+	return
+}
+
+// Put adds key and value into o. The key is appended to the end if it is new.
+func (o *Ordered[K, V]) Put(key K, val V) *Ordered[K, V] {
+	if e, ok := o.m[key]; ok {
+		e.value = val
+		return o
+	}
+
+	if o.m == nil {
+		o.m = make(map[K]*entry[K, V])
+	}
+
+	e := &entry[K, V]{key: key, value: val, prev: o.tail}
+	if o.tail == nil {
+		o.head = e
+	} else {
+		o.tail.next = e
+	}
+	o.tail = e
+	o.m[key] = e
+	return o
+}
+
+// Add adds pairs into o in order.
+func (o *Ordered[K, V]) Add(pairs ...Pair[K, V]) *Ordered[K, V] {
+	for _, p := range pairs {
+		o.Put(p.Key, p.Value)
+	}
+	return o
+}
+
+// Merge adds all elements of another map a into o in order of a.
+func (o *Ordered[K, V]) Merge(a *Ordered[K, V]) *Ordered[K, V] {
+	a.Foreach(func(k K, v V) {
+		o.Put(k, v)
+	})
+	return o
+}
+
+// Remove removes given keys from o.
+func (o *Ordered[K, V]) Remove(keys ...K) *Ordered[K, V] {
+	for _, key := range keys {
+		e, ok := o.m[key]
+		if !ok {
+			continue
+		}
+
+		if e.prev == nil {
+			o.head = e.next
+		} else {
+			e.prev.next = e.next
+		}
+
+		if e.next == nil {
+			o.tail = e.prev
+		} else {
+			e.next.prev = e.prev
+		}
+		delete(o.m, key)
+	}
+	return o
+}
+
+// Clone returns a copy of o.
+func (o *Ordered[K, V]) Clone() *Ordered[K, V] {
+	return new(Ordered[K, V]).Merge(o)
+}
+
+// Keys returns a slice of all keys in order.
+func (o *Ordered[K, V]) Keys() slices.S[K] {
+	return FoldOrdered(o, make(slices.S[K], 0, o.Len()), func(z slices.S[K], k K, _ V) slices.S[K] {
+		return append(z, k)
+	})
+}
+
+// Values returns a slice of all values in order of keys.
+func (o *Ordered[K, V]) Values() slices.S[V] {
+	return FoldOrdered(o, make(slices.S[V], 0, o.Len()), func(z slices.S[V], _ K, v V) slices.S[V] {
+		return append(z, v)
+	})
+}
+
+// Count returns numbers of elements in o satisfying given function p.
+func (o *Ordered[K, V]) Count(p func(K, V) bool) int {
+	return FoldOrdered(o, 0, func(a int, k K, v V) int {
+		return funcs.Cond(p(k, v), a+1, a)
+	})
+}
+
+// Find returns the first key-value pair of o satisfying given function p.
+func (o *Ordered[K, V]) Find(p func(K, V) bool) gs.Option[Pair[K, V]] {
+	for e := o.head; e != nil; e = e.next {
+		if p(e.key, e.value) {
+			return gs.Some(P(e.key, e.value))
+		}
+	}
+	return gs.None[Pair[K, V]]()
+}
+
+// Exists return true if at least one element in o satisfies given function p.
+func (o *Ordered[K, V]) Exists(p func(K, V) bool) bool {
+	return o.Find(p).IsDefined()
+}
+
+// Forall returns true if o is empty or all elements satisfy given function p.
+func (o *Ordered[K, V]) Forall(p func(K, V) bool) bool {
+	return !o.Exists(func(k K, v V) bool { return !p(k, v) })
+}
+
+// Foreach applies given function op to each element in o in order.
+func (o *Ordered[K, V]) Foreach(op func(K, V)) {
+	for e := o.head; e != nil; e = e.next {
+		op(e.key, e.value)
+	}
+}
+
+// Filter returns a new map made of elements in o satisfying given function p.
+func (o *Ordered[K, V]) Filter(p func(K, V) bool) *Ordered[K, V] {
+	return FoldOrdered(o, new(Ordered[K, V]), func(z *Ordered[K, V], k K, v V) *Ordered[K, V] {
+		if p(k, v) {
+			z.Put(k, v)
+		}
+		return z
+	})
+}
+
+// FilterNot returns a new map made of elements in o not satisfying given function p.
+func (o *Ordered[K, V]) FilterNot(p func(K, V) bool) *Ordered[K, V] {
+	return o.Filter(func(k K, v V) bool { return !p(k, v) })
+}
+
+// Partition partitions o into two maps according to given function p. The first map made of elements in o not satisfying the function p, and the second map made of elements satisfying the function p.
+func (o *Ordered[K, V]) Partition(p func(K, V) bool) (_, _ *Ordered[K, V]) {
+	a, b := new(Ordered[K, V]), new(Ordered[K, V])
+	o.Foreach(func(k K, v V) {
+		if p(k, v) {
+			b.Put(k, v)
+		} else {
+			a.Put(k, v)
+		}
+	})
+	return a, b
+}
+
+// Slice returns a slice containing key-value pairs from o in order.
+func (o *Ordered[K, V]) Slice() slices.S[Pair[K, V]] {
+	return FoldOrdered(o, make(slices.S[Pair[K, V]], 0, o.Len()), func(z slices.S[Pair[K, V]], k K, v V) slices.S[Pair[K, V]] {
+		return append(z, P(k, v))
+	})
+}
+
+// M returns a map containing all elements of o.
+func (o *Ordered[K, V]) M() M[K, V] {
+	return FoldOrdered(o, make(M[K, V], o.Len()), func(z M[K, V], k K, v V) M[K, V] {
+		return z.Put(k, v)
+	})
+}
+
+func (o *Ordered[K, V]) String() string {
+	return fmt.Sprintf(`Ordered(%s)`, strings.Join(pairStrings(o.Slice()), ", "))
+}
+
+func (o *Ordered[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalPairs(o.Slice())
+}
+
+// UnmarshalJSON replaces elements of o with pairs decoded from given JSON object data in order. It does nothing if data is null.
+func (o *Ordered[K, V]) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		return nil
+	}
+
+	pairs, err := unmarshalPairs[K, V](data)
+	if err != nil {
+		return err
+	}
+	*o = *NewOrdered(pairs...)
+	return nil
+}
+
+// pairStrings returns strings of given pairs in form of "key -> value".
+func pairStrings[K comparable, V any](pairs slices.S[Pair[K, V]]) slices.S[string] {
+	return slices.Map(pairs, func(p Pair[K, V]) string {
+		return fmt.Sprintf(`%v -> %v`, p.Key, p.Value)
+	})
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// FoldOrdered applies given function op to a start value z and all elements of o in order.
+func FoldOrdered[K comparable, V, U any](o *Ordered[K, V], z U, op func(U, K, V) U) (ret U) {
+	ret = z
+	for e := o.head; e != nil; e = e.next {
+		ret = op(ret, e.key, e.value)
+	}
+	return
+}
+
+// MapOrdered returns a new map by applying given function op to all elements of o in order.
+func MapOrdered[K1, K2 comparable, V1, V2 any](o *Ordered[K1, V1], op func(K1, V1) (K2, V2)) *Ordered[K2, V2] {
+	return FoldOrdered(o, new(Ordered[K2, V2]), func(z *Ordered[K2, V2], k K1, v V1) *Ordered[K2, V2] {
+		return z.Put(op(k, v))
+	})
+}
+
+// FlatMapOrdered returns a new map by applying given function op to all elements of o and merging results in order.
+func FlatMapOrdered[K1, K2 comparable, V1, V2 any](o *Ordered[K1, V1], op func(K1, V1) *Ordered[K2, V2]) *Ordered[K2, V2] {
+	return FoldOrdered(o, new(Ordered[K2, V2]), func(z *Ordered[K2, V2], k K1, v V1) *Ordered[K2, V2] {
+		return z.Merge(op(k, v))
+	})
+}
+
+// GroupByOrdered partitions o into a map of maps according to given discriminator function key.
+// Groups are in order of their first elements, and elements in each group keep their order in o.
+func GroupByOrdered[K, K1 comparable, V any](o *Ordered[K, V], key func(K, V) K1) *Ordered[K1, *Ordered[K, V]] {
+	return FoldOrdered(o, new(Ordered[K1, *Ordered[K, V]]), func(z *Ordered[K1, *Ordered[K, V]], k K, v V) *Ordered[K1, *Ordered[K, V]] {
+		k1 := key(k, v)
+		if !z.Contain(k1) {
+			z.Put(k1, new(Ordered[K, V]))
+		}
+		z.Get(k1).Get().Put(k, v)
+		return z
+	})
+}
@@ -0,0 +1,435 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/slices"
+)
+
+// node is a node of left-leaning red-black tree.
+type node[K comparable, V any] struct {
+	_     struct{}
+	key   K
+	value V
+	left  *node[K, V]
+	right *node[K, V]
+	red   bool
+}
+
+func (n *node[K, V]) isRed() bool {
+	return n != nil && n.red
+}
+
+func (n *node[K, V]) rotateLeft() *node[K, V] {
+	x := n.right
+	n.right = x.left
+	x.left = n
+	x.red = n.red
+	n.red = true
+	return x
+}
+
+func (n *node[K, V]) rotateRight() *node[K, V] {
+	x := n.left
+	n.left = x.right
+	x.right = n
+	x.red = n.red
+	n.red = true
+	return x
+}
+
+func (n *node[K, V]) flip() {
+	n.red = !n.red
+	n.left.red = !n.left.red
+	n.right.red = !n.right.red
+}
+
+func (n *node[K, V]) balance() *node[K, V] {
+	if n.right.isRed() && !n.left.isRed() {
+		n = n.rotateLeft()
+	}
+	if n.left.isRed() && n.left.left.isRed() {
+		n = n.rotateRight()
+	}
+	if n.left.isRed() && n.right.isRed() {
+		n.flip()
+	}
+	return n
+}
+
+func (n *node[K, V]) moveRedLeft() *node[K, V] {
+	n.flip()
+	if n.right.left.isRed() {
+		n.right = n.right.rotateRight()
+		n = n.rotateLeft()
+		n.flip()
+	}
+	return n
+}
+
+func (n *node[K, V]) moveRedRight() *node[K, V] {
+	n.flip()
+	if n.left.left.isRed() {
+		n = n.rotateRight()
+		n.flip()
+	}
+	return n
+}
+
+func (n *node[K, V]) min() *node[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *node[K, V]) max() *node[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func (n *node[K, V]) deleteMin() *node[K, V] {
+	if n.left == nil {
+		return nil
+	}
+	if !n.left.isRed() && !n.left.left.isRed() {
+		n = n.moveRedLeft()
+	}
+	n.left = n.left.deleteMin()
+	return n.balance()
+}
+
+// each applies given function op to nodes of n in order until op returns false.
+func (n *node[K, V]) each(op func(*node[K, V]) bool) bool {
+	return n == nil || (n.left.each(op) && op(n) && n.right.each(op))
+}
+
+func (n *node[K, V]) pair() gs.Option[Pair[K, V]] {
+	if n == nil {
+		return gs.None[Pair[K, V]]()
+	}
+	return gs.Some(P(n.key, n.value))
+}
+
+// ErrNoOrdering represents a Sorted is used without ordering function.
+var ErrNoOrdering = errors.New("no ordering")
+
+// Sorted is a map keeping keys sorted by an ordering function. It is backed by a left-leaning red-black tree.
+// Use NewSorted to create one, because the zero value has no ordering function.
+type Sorted[K comparable, V any] struct {
+	_    struct{}
+	cmp  funcs.Ordering[K, K]
+	root *node[K, V]
+	size int
+}
+
+// NewSorted returns a Sorted with given ordering function cmp and pairs.
+// Use funcs.Order as cmp for keys of constraints.Ordered.
+func NewSorted[K comparable, V any](cmp funcs.Ordering[K, K], pairs ...Pair[K, V]) *Sorted[K, V] {
+	return (&Sorted[K, V]{cmp: cmp}).Add(pairs...)
+}
+
+// Sorted returns a Sorted containing elements of m with keys sorted by given cmp.
+func (m M[K, V]) Sorted(cmp funcs.Ordering[K, K]) *Sorted[K, V] {
+	return Fold(m, NewSorted[K, V](cmp), func(z *Sorted[K, V], k K, v V) *Sorted[K, V] {
+		return z.Put(k, v)
+	})
+}
+
+func (s *Sorted[K, V]) find(key K) *node[K, V] {
+	n := s.root
+	for n != nil {
+		switch c := s.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func (s *Sorted[K, V]) put(n *node[K, V], key K, val V) *node[K, V] {
+	if n == nil {
+		s.size++
+		return &node[K, V]{key: key, value: val, red: true}
+	}
+
+	switch c := s.cmp(key, n.key); {
+	case c < 0:
+		n.left = s.put(n.left, key, val)
+	case c > 0:
+		n.right = s.put(n.right, key, val)
+	default:
+		n.value = val
+	}
+	return n.balance()
+}
+
+// remove removes given key from n. The key must be in n.
+func (s *Sorted[K, V]) remove(n *node[K, V], key K) *node[K, V] {
+	if s.cmp(key, n.key) < 0 {
+		if !n.left.isRed() && !n.left.left.isRed() {
+			n = n.moveRedLeft()
+		}
+		n.left = s.remove(n.left, key)
+		return n.balance()
+	}
+
+	if n.left.isRed() {
+		n = n.rotateRight()
+	}
+	if s.cmp(key, n.key) == 0 && n.right == nil {
+		return nil
+	}
+	if !n.right.isRed() && !n.right.left.isRed() {
+		n = n.moveRedRight()
+	}
+	if s.cmp(key, n.key) == 0 {
+		m := n.right.min()
+		n.key, n.value = m.key, m.value
+		n.right = n.right.deleteMin()
+	} else {
+		n.right = s.remove(n.right, key)
+	}
+	return n.balance()
+}
+
+// Len returns numbers of elements in s.
+func (s *Sorted[K, V]) Len() int {
+	return s.size
+}
+
+// IsEmpty returns true if s has no element.
+func (s *Sorted[K, V]) IsEmpty() bool {
+	return s.size <= 0
+}
+
+// Get returns Some with value of given key, or returns None if s does not have the key.
+func (s *Sorted[K, V]) Get(key K) gs.Option[V] {
+	if n := s.find(key); n != nil {
+		return gs.Some(n.value)
+	}
+	return gs.None[V]()
+}
+
+// Contain returns true if s has given key x.
+func (s *Sorted[K, V]) Contain(x K) bool {
+	return s.find(x) != nil
+}
+
+// Put adds key and value into s.
+func (s *Sorted[K, V]) Put(key K, val V) *Sorted[K, V] {
+	s.root = s.put(s.root, key, val)
+	s.root.red = false
+	return s
+}
+
+// Add adds pairs into s.
+func (s *Sorted[K, V]) Add(pairs ...Pair[K, V]) *Sorted[K, V] {
+	for _, p := range pairs {
+		s.Put(p.Key, p.Value)
+	}
+	return s
+}
+
+// Remove removes given keys from s.
+func (s *Sorted[K, V]) Remove(keys ...K) *Sorted[K, V] {
+	for _, key := range keys {
+		if !s.Contain(key) {
+			continue
+		}
+
+		if !s.root.left.isRed() && !s.root.right.isRed() {
+			s.root.red = true
+		}
+		s.root = s.remove(s.root, key)
+		if s.root != nil {
+			s.root.red = false
+		}
+		s.size--
+	}
+	return s
+}
+
+// Head returns Some with the pair of the smallest key, or returns None if s is empty.
+func (s *Sorted[K, V]) Head() gs.Option[Pair[K, V]] {
+	if s.root == nil {
+		return gs.None[Pair[K, V]]()
+	}
+	return s.root.min().pair()
+}
+
+// Last returns Some with the pair of the largest key, or returns None if s is empty.
+func (s *Sorted[K, V]) Last() gs.Option[Pair[K, V]] {
+	if s.root == nil {
+		return gs.None[Pair[K, V]]()
+	}
+	return s.root.max().pair()
+}
+
+// Floor returns Some with the pair of the largest key less than or equal to given key, or returns None if there is no such key.
+func (s *Sorted[K, V]) Floor(key K) gs.Option[Pair[K, V]] {
+	var ret *node[K, V]
+	for n := s.root; n != nil; {
+		switch c := s.cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			ret, n = n, n.right
+		default:
+			return n.pair()
+		}
+	}
+	return ret.pair()
+}
+
+// Ceiling returns Some with the pair of the smallest key greater than or equal to given key, or returns None if there is no such key.
+func (s *Sorted[K, V]) Ceiling(key K) gs.Option[Pair[K, V]] {
+	var ret *node[K, V]
+	for n := s.root; n != nil; {
+		switch c := s.cmp(key, n.key); {
+		case c < 0:
+			ret, n = n, n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.pair()
+		}
+	}
+	return ret.pair()
+}
+
+// Range returns a new Sorted containing elements of s with keys in [from, to).
+func (s *Sorted[K, V]) Range(from, to K) *Sorted[K, V] {
+	ret := NewSorted[K, V](s.cmp)
+
+	var visit func(*node[K, V])
+	visit = func(n *node[K, V]) {
+		if n == nil {
+			return
+		}
+
+		lower, upper := s.cmp(from, n.key) <= 0, s.cmp(n.key, to) < 0
+		if lower {
+			visit(n.left)
+		}
+		if lower && upper {
+			ret.Put(n.key, n.value)
+		}
+		if upper {
+			visit(n.right)
+		}
+	}
+
+	visit(s.root)
+	return ret
+}
+
+// Clone returns a copy of s.
+func (s *Sorted[K, V]) Clone() *Sorted[K, V] {
+	return FoldSorted(s, NewSorted[K, V](s.cmp), func(z *Sorted[K, V], k K, v V) *Sorted[K, V] {
+		return z.Put(k, v)
+	})
+}
+
+// Foreach applies given function op to each element in s in order of keys.
+func (s *Sorted[K, V]) Foreach(op func(K, V)) {
+	s.root.each(func(n *node[K, V]) bool {
+		op(n.key, n.value)
+		return true
+	})
+}
+
+// Find returns the first key-value pair of s in order of keys satisfying given function p.
+func (s *Sorted[K, V]) Find(p func(K, V) bool) gs.Option[Pair[K, V]] {
+	var ret *node[K, V]
+	s.root.each(func(n *node[K, V]) bool {
+		if p(n.key, n.value) {
+			ret = n
+			return false
+		}
+		return true
+	})
+	return ret.pair()
+}
+
+// Keys returns a slice of all keys in order.
+func (s *Sorted[K, V]) Keys() slices.S[K] {
+	return FoldSorted(s, make(slices.S[K], 0, s.size), func(z slices.S[K], k K, _ V) slices.S[K] {
+		return append(z, k)
+	})
+}
+
+// Values returns a slice of all values in order of keys.
+func (s *Sorted[K, V]) Values() slices.S[V] {
+	return FoldSorted(s, make(slices.S[V], 0, s.size), func(z slices.S[V], _ K, v V) slices.S[V] {
+		return append(z, v)
+	})
+}
+
+// Slice returns a slice containing key-value pairs from s in order of keys.
+func (s *Sorted[K, V]) Slice() slices.S[Pair[K, V]] {
+	return FoldSorted(s, make(slices.S[Pair[K, V]], 0, s.size), func(z slices.S[Pair[K, V]], k K, v V) slices.S[Pair[K, V]] {
+		return append(z, P(k, v))
+	})
+}
+
+// M returns a map containing all elements of s.
+func (s *Sorted[K, V]) M() M[K, V] {
+	return FoldSorted(s, make(M[K, V], s.size), func(z M[K, V], k K, v V) M[K, V] {
+		return z.Put(k, v)
+	})
+}
+
+func (s *Sorted[K, V]) String() string {
+	return fmt.Sprintf(`Sorted(%s)`, strings.Join(pairStrings(s.Slice()), ", "))
+}
+
+func (s *Sorted[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalPairs(s.Slice())
+}
+
+// UnmarshalJSON adds elements decoded from given JSON object data into s. s must have an ordering function.
+// It does nothing if data is null.
+func (s *Sorted[K, V]) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		return nil
+	}
+
+	if s.cmp == nil {
+		return ErrNoOrdering
+	}
+
+	pairs, err := unmarshalPairs[K, V](data)
+	if err != nil {
+		return err
+	}
+	s.Add(pairs...)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// FoldSorted applies given function op to a start value z and all elements of s in order of keys.
+func FoldSorted[K comparable, V, U any](s *Sorted[K, V], z U, op func(U, K, V) U) U {
+	s.Foreach(func(k K, v V) {
+		z = op(z, k, v)
+	})
+	return z
+}
@@ -6,6 +6,7 @@
 package gs
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -75,6 +76,14 @@ func (o *option[T]) String() string {
 	return fmt.Sprintf(`None(%s)`, reflect.TypeOf(o.right).String())
 }
 
+// MarshalJSON encodes a None to null, or encodes value of a Some.
+func (o *option[T]) MarshalJSON() ([]byte, error) {
+	if o.ok {
+		return json.Marshal(o.right)
+	}
+	return []byte(`null`), nil
+}
+
 func (o *option[T]) Fetch() (T, error) {
 	return o.right, o.left
 }
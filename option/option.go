@@ -33,6 +33,33 @@ func Unless[T any](p funcs.Condition, z T) gs.Option[T] {
 	return From(z, !p())
 }
 
+// nils returns an Ordering of Option comparing values of Some with given o, and None is less than Some if given first is true.
+func nils[T any](o funcs.Ordering[T, T], first bool) funcs.Ordering[gs.Option[T], gs.Option[T]] {
+	none := funcs.Cond(first, -1, 1)
+	return func(a, b gs.Option[T]) int {
+		switch {
+		case a.IsEmpty() && b.IsEmpty():
+			return 0
+		case a.IsEmpty():
+			return none
+		case b.IsEmpty():
+			return -none
+		default:
+			return o(a.Get(), b.Get())
+		}
+	}
+}
+
+// NilsFirst returns an Ordering of Option placing None before Some, and comparing values of Some with given o.
+func NilsFirst[T any](o funcs.Ordering[T, T]) funcs.Ordering[gs.Option[T], gs.Option[T]] {
+	return nils(o, true)
+}
+
+// NilsLast returns an Ordering of Option placing None after Some, and comparing values of Some with given o.
+func NilsLast[T any](o funcs.Ordering[T, T]) funcs.Ordering[gs.Option[T], gs.Option[T]] {
+	return nils(o, false)
+}
+
 // -----------------------------------------------------------------------------
 
 // TODO: refactor following functions to methods when go 1.19 releases.
@@ -6,13 +6,20 @@
 package option_test
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"io"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
 	"github.com/dairaga/gs/option"
+	"github.com/dairaga/gs/slices"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -121,3 +128,251 @@ func TestRight(t *testing.T) {
 	assert.True(t, e.IsLeft())
 	assert.Equal(t, "1", e.Left())
 }
+
+func TestOJSON(t *testing.T) {
+	type st struct {
+		A option.O[string]             `json:"a"`
+		B option.O[slices.S[int]]      `json:"b"`
+		C option.O[option.O[int]]      `json:"c"`
+		D option.O[map[string]float64] `json:"d"`
+	}
+
+	src := st{
+		A: option.Of(gs.Some("a")),
+		B: option.Of(gs.Some(slices.From(1, 2, 3))),
+		C: option.Of(gs.Some(option.Of(gs.Some(1)))),
+	}
+
+	data, err := json.Marshal(src)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"a","b":[1,2,3],"c":1,"d":null}`, string(data))
+
+	var dst st
+	assert.Nil(t, json.Unmarshal(data, &dst))
+	assertOption(t, gs.Some("a"), dst.A.Option())
+	assertOption(t, gs.Some(slices.From(1, 2, 3)), dst.B.Option())
+	assertOption(t, gs.Some(1), dst.C.Get().Option())
+	assertOption(t, gs.None[map[string]float64](), dst.D.Option())
+
+	dst = st{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"b":null}`), &dst))
+	assertOption(t, gs.None[string](), dst.A.Option())
+	assertOption(t, gs.None[slices.S[int]](), dst.B.Option())
+	assert.Equal(t, `None(string)`, dst.A.String())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":1}`), &dst))
+
+	src = st{C: option.Of(gs.Some(option.Of(gs.None[int]())))}
+	data, err = json.Marshal(src)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":null,"b":null,"c":null,"d":null}`, string(data))
+	dst = st{}
+	assert.Nil(t, json.Unmarshal(data, &dst))
+	assert.True(t, dst.C.IsEmpty())
+}
+
+func TestO(t *testing.T) {
+	var o option.O[int]
+	assert.True(t, o.IsEmpty())
+	assert.True(t, o.IsZero())
+	assert.Equal(t, 1, o.GetOrElse(1))
+	assert.True(t, option.Of(gs.None[int]()).IsZero())
+	assert.False(t, option.Of(gs.Some(0)).IsZero())
+
+	o = option.Of(gs.Some(2))
+	assert.True(t, o.IsDefined())
+	assert.Equal(t, 2, o.Get())
+	assert.True(t, o.Exists(func(v int) bool { return v == 2 }))
+	assertOption(t, gs.None[int](), o.Filter(func(v int) bool { return v > 2 }))
+}
+
+// fakeDriver is a database driver and connector storing inserted rows in memory.
+type fakeDriver struct {
+	rows [][]driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return d
+}
+
+func (d *fakeDriver) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: d, query: query}, nil
+}
+
+func (d *fakeDriver) Close() error {
+	return nil
+}
+
+func (d *fakeDriver) Begin() (driver.Tx, error) {
+	return nil, gs.ErrUnsupported
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.rows = append(s.d.rows, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string {
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func TestOSQL(t *testing.T) {
+	d := &fakeDriver{}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	now := time.Now().UTC()
+
+	_, err := db.Exec(`insert`,
+		option.Of(gs.Some("a")),
+		option.Of(gs.Some(int32(1))),
+		option.Of(gs.Some(uint8(2))),
+		option.Of(gs.Some(1.5)),
+		option.Of(gs.Some(true)),
+		option.Of(gs.Some(now)),
+		option.Of(gs.Some([]byte("b"))),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t,
+		[]driver.Value{"a", int64(1), int64(2), 1.5, true, now, []byte("b")},
+		d.rows[0],
+	)
+
+	_, err = db.Exec(`insert`,
+		option.O[string]{},
+		option.Of(gs.None[int32]()),
+		option.Of(gs.None[uint8]()),
+		option.Of(gs.None[float64]()),
+		option.Of(gs.None[bool]()),
+		option.Of(gs.None[time.Time]()),
+		option.Of(gs.None[[]byte]()),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []driver.Value{nil, nil, nil, nil, nil, nil, nil}, d.rows[1])
+
+	rows, err := db.Query(`select`)
+	assert.Nil(t, err)
+	defer rows.Close()
+
+	var (
+		s  option.O[string]
+		i  option.O[int32]
+		u  option.O[uint8]
+		f  option.O[float64]
+		b  option.O[bool]
+		tm option.O[time.Time]
+		bs option.O[[]byte]
+	)
+
+	assert.True(t, rows.Next())
+	assert.Nil(t, rows.Scan(&s, &i, &u, &f, &b, &tm, &bs))
+	assertOption(t, gs.Some("a"), s.Option())
+	assertOption(t, gs.Some(int32(1)), i.Option())
+	assertOption(t, gs.Some(uint8(2)), u.Option())
+	assertOption(t, gs.Some(1.5), f.Option())
+	assertOption(t, gs.Some(true), b.Option())
+	assertOption(t, gs.Some(now), tm.Option())
+	assertOption(t, gs.Some([]byte("b")), bs.Option())
+
+	assert.True(t, rows.Next())
+	assert.Nil(t, rows.Scan(&s, &i, &u, &f, &b, &tm, &bs))
+	assert.True(t, s.IsEmpty())
+	assert.True(t, i.IsEmpty())
+	assert.True(t, u.IsEmpty())
+	assert.True(t, f.IsEmpty())
+	assert.True(t, b.IsEmpty())
+	assert.True(t, tm.IsEmpty())
+	assert.True(t, bs.IsEmpty())
+
+	assert.False(t, rows.Next())
+}
+
+func TestOScan(t *testing.T) {
+	var i option.O[int]
+	assert.Nil(t, i.Scan([]byte("12")))
+	assertOption(t, gs.Some(12), i.Option())
+	assert.Nil(t, i.Scan(float64(3)))
+	assertOption(t, gs.Some(3), i.Option())
+	assert.NotNil(t, i.Scan("abc"))
+	assert.NotNil(t, i.Scan(true))
+	assert.NotNil(t, i.Scan(time.Now()))
+
+	var s option.O[string]
+	assert.Nil(t, s.Scan(int64(5)))
+	assertOption(t, gs.Some("5"), s.Option())
+	assert.Nil(t, s.Scan([]byte("x")))
+	assertOption(t, gs.Some("x"), s.Option())
+
+	var f option.O[float32]
+	assert.Nil(t, f.Scan("1.25"))
+	assertOption(t, gs.Some(float32(1.25)), f.Option())
+
+	var b option.O[bool]
+	assert.Nil(t, b.Scan(int64(1)))
+	assertOption(t, gs.Some(true), b.Option())
+
+	var ns option.O[sql.NullString]
+	assert.Nil(t, ns.Scan("y"))
+	assertOption(t, gs.Some(sql.NullString{String: "y", Valid: true}), ns.Option())
+
+	var tm option.O[time.Time]
+	now := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	assert.Nil(t, tm.Scan(now.Format(time.RFC3339Nano)))
+	assertOption(t, gs.Some(now), tm.Option())
+}
+
+func TestNils(t *testing.T) {
+	s := slices.From(gs.Some(2), gs.None[int](), gs.Some(1), gs.None[int]())
+
+	first := s.Clone().Sort(option.NilsFirst(funcs.Order[int]))
+	assert.Equal(t, slices.From(gs.None[int](), gs.None[int](), gs.Some(1), gs.Some(2)), first)
+
+	last := s.Clone().Sort(option.NilsLast(funcs.Order[int]))
+	assert.Equal(t, slices.From(gs.Some(1), gs.Some(2), gs.None[int](), gs.None[int]()), last)
+
+	desc := s.Clone().Sort(option.NilsLast(funcs.Reverse(funcs.Order[int])))
+	assert.Equal(t, slices.From(gs.Some(2), gs.Some(1), gs.None[int](), gs.None[int]()), desc)
+}
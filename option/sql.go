@@ -0,0 +1,149 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package option
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/dairaga/gs"
+)
+
+var (
+	_ sql.Scanner   = &O[int]{}
+	_ driver.Valuer = O[int]{}
+)
+
+// Scan implements sql.Scanner. SQL NULL is scanned to a None, or a Some with value converted from given src.
+func (o *O[T]) Scan(src interface{}) error {
+	if src == nil {
+		o.o = gs.None[T]()
+		return nil
+	}
+
+	v, err := convert[T](src)
+	if err != nil {
+		return err
+	}
+	o.o = gs.Some(v)
+	return nil
+}
+
+// Value implements driver.Valuer. A None is valued as SQL NULL, or value of a Some is converted to driver.Value.
+func (o O[T]) Value() (driver.Value, error) {
+	v, ok := o.Check()
+	if !ok {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// convert converts given src from database driver to T.
+func convert[T any](src interface{}) (dst T, err error) {
+	if v, ok := src.(T); ok {
+		return v, nil
+	}
+
+	if scanner, ok := interface{}(&dst).(sql.Scanner); ok {
+		err = scanner.Scan(src)
+		return
+	}
+
+	dv := reflect.ValueOf(&dst).Elem()
+	switch v := src.(type) {
+	case []byte:
+		if dv.Kind() == reflect.Slice && dv.Type().Elem().Kind() == reflect.Uint8 {
+			dv.SetBytes(append([]byte{}, v...))
+			return
+		}
+		err = parse(dv, string(v))
+	case string:
+		err = parse(dv, v)
+	case time.Time:
+		if dv.Kind() != reflect.String {
+			err = fmt.Errorf(`option: unsupported Scan, storing %T into %T`, src, dst)
+			return
+		}
+		dv.SetString(v.Format(time.RFC3339Nano))
+	default:
+		sk := reflect.ValueOf(src).Kind()
+		switch {
+		case !number(sk) && sk != reflect.Bool:
+			err = fmt.Errorf(`option: unsupported Scan, storing %T into %T`, src, dst)
+		case dv.Kind() == reflect.String:
+			dv.SetString(fmt.Sprint(src))
+		case dv.Kind() == reflect.Bool:
+			var b driver.Value
+			if b, err = driver.Bool.ConvertValue(src); err == nil {
+				dv.SetBool(b.(bool))
+			}
+		case number(dv.Kind()) && number(sk):
+			err = parse(dv, fmt.Sprint(src))
+		default:
+			err = fmt.Errorf(`option: unsupported Scan, storing %T into %T`, src, dst)
+		}
+	}
+	return
+}
+
+// number returns true if given reflect kind k is an integer or a float.
+func number(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// parse parses given text s into dv according to its kind.
+func parse(dv reflect.Value, s string) error {
+	if _, ok := dv.Interface().(time.Time); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err == nil {
+			dv.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+
+	switch dv.Kind() {
+	case reflect.String:
+		dv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, dv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, dv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dv.SetFloat(f)
+	default:
+		return fmt.Errorf(`option: unsupported Scan, storing %q into %s`, s, dv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package option
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+)
+
+// O is a value type of Option, and can be a field of struct. Zero value of O is a None.
+// O is encoded to JSON null if it is a None, or encoded to its value.
+// A nested Some(None) such as O[O[T]] is encoded to null as well, and decoded to None, since JSON can not tell them apart.
+// Use IsZero with omitzero tag in Go 1.24 or later to omit a None field.
+type O[T any] struct {
+	_ struct{}
+	o gs.Option[T]
+}
+
+var _ gs.Option[int] = O[int]{}
+
+// Of returns a O from given Option o.
+func Of[T any](o gs.Option[T]) O[T] {
+	return O[T]{o: o}
+}
+
+// Option returns Option of this.
+func (o O[T]) Option() gs.Option[T] {
+	if o.o == nil {
+		return gs.None[T]()
+	}
+	return o.o
+}
+
+func (o O[T]) String() string {
+	return o.Option().String()
+}
+
+func (o O[T]) Fetch() (T, error) {
+	return o.Option().Fetch()
+}
+
+func (o O[T]) Check() (T, bool) {
+	return o.Option().Check()
+}
+
+func (o O[T]) Get() T {
+	return o.Option().Get()
+}
+
+func (o O[T]) IsDefined() bool {
+	return o.Option().IsDefined()
+}
+
+func (o O[T]) IsEmpty() bool {
+	return o.Option().IsEmpty()
+}
+
+func (o O[T]) Exists(p funcs.Predict[T]) bool {
+	return o.Option().Exists(p)
+}
+
+func (o O[T]) Forall(p funcs.Predict[T]) bool {
+	return o.Option().Forall(p)
+}
+
+func (o O[T]) Foreach(op func(T)) {
+	o.Option().Foreach(op)
+}
+
+func (o O[T]) Filter(p funcs.Predict[T]) gs.Option[T] {
+	return o.Option().Filter(p)
+}
+
+func (o O[T]) FilterNot(p funcs.Predict[T]) gs.Option[T] {
+	return o.Option().FilterNot(p)
+}
+
+func (o O[T]) GetOrElse(z T) T {
+	return o.Option().GetOrElse(z)
+}
+
+func (o O[T]) OrElse(z gs.Option[T]) gs.Option[T] {
+	return o.Option().OrElse(z)
+}
+
+func (o O[T]) Try() gs.Try[T] {
+	return o.Option().Try()
+}
+
+func (o O[T]) Either() gs.Either[error, T] {
+	return o.Option().Either()
+}
+
+// IsZero returns true if o is a None. encoding/json in Go 1.24 or later omits a field with omitzero tag if IsZero returns true.
+func (o O[T]) IsZero() bool {
+	return o.IsEmpty()
+}
+
+func (o O[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Option())
+}
+
+func (o *O[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte(`null`)) {
+		o.o = gs.None[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.o = gs.Some(v)
+	return nil
+}
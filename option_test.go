@@ -6,6 +6,7 @@
 package gs_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -155,3 +156,21 @@ func TestOptionOrElse(t *testing.T) {
 	assertOption(t, gs.Some(100).OrElse(z), gs.Some(100))
 	assertOption(t, gs.None[int]().OrElse(z), z)
 }
+
+func TestOptionMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(gs.Some("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, `"a"`, string(data))
+
+	data, err = json.Marshal(gs.None[string]())
+	assert.Nil(t, err)
+	assert.Equal(t, `null`, string(data))
+
+	type st struct {
+		A gs.Option[int] `json:"a,omitempty"`
+		B gs.Option[int] `json:"b"`
+	}
+	data, err = json.Marshal(st{B: gs.Some(1)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"b":1}`, string(data))
+}
@@ -0,0 +1,193 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/dairaga/gs/funcs"
+)
+
+// Clock provides current time and timer. It is replaceable for deterministic tests.
+type Clock interface {
+	// Now returns current time.
+	Now() time.Time
+
+	// After waits for given duration d and then sends current time on returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is a Clock from package time.
+var SystemClock Clock = systemClock{}
+
+// Random provides random numbers for jitter. It is replaceable for deterministic tests, and *rand.Rand implements it.
+type Random interface {
+	// Int63n returns a random number in [0, n). n must be larger than 0.
+	Int63n(n int64) int64
+}
+
+type systemRandom struct{}
+
+func (systemRandom) Int63n(n int64) int64 {
+	return rand.Int63n(n)
+}
+
+// SystemRandom is a Random from package math/rand.
+var SystemRandom Random = systemRandom{}
+
+// Backoff is a function returns delay before next attempt from given numbers of failed attempts n, and previous delay prev.
+type Backoff func(n int, prev time.Duration) time.Duration
+
+// jitter is a Backoff taking random numbers from given r.
+type jitter func(r Random, n int, prev time.Duration) time.Duration
+
+// Policy decides whether and when to retry a failed operation.
+type Policy struct {
+	_         struct{}
+	backoff   jitter
+	attempts  int
+	elapsed   time.Duration
+	retryable funcs.Predict[error]
+	clock     Clock
+	random    Random
+}
+
+// fromJitter returns a Policy with given jitter j, unlimited attempts and elapsed time, and retries all errors.
+func fromJitter(j jitter) Policy {
+	return Policy{
+		backoff: j,
+		clock:   SystemClock,
+		random:  SystemRandom,
+	}
+}
+
+// From returns a Policy with given backoff b, unlimited attempts and elapsed time, and retries all errors.
+func From(b Backoff) Policy {
+	if b == nil {
+		return fromJitter(nil)
+	}
+	return fromJitter(func(_ Random, n int, prev time.Duration) time.Duration {
+		return b(n, prev)
+	})
+}
+
+// Constant returns a Policy waiting given delay d between attempts.
+func Constant(d time.Duration) Policy {
+	return From(func(int, time.Duration) time.Duration {
+		return d
+	})
+}
+
+// exponential returns base * 2^(n-1), and not larger than given max if max is larger than 0.
+// It saturates at math.MaxInt64 instead of overflowing if max is not larger than 0.
+func exponential(base, max time.Duration, n int) time.Duration {
+	limit := funcs.Cond(max > 0, max, time.Duration(math.MaxInt64))
+	if n <= 1 || base <= 0 {
+		return funcs.Cond(base > limit, limit, base)
+	}
+
+	// base << shift is not larger than limit if base is not larger than limit >> shift.
+	shift := uint(n - 1)
+	if base > limit>>shift {
+		return limit
+	}
+	return base << shift
+}
+
+// between returns a random duration in [min, max) from given r.
+func between(r Random, min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(r.Int63n(int64(max-min)))
+}
+
+// Exponential returns a Policy waiting base * 2^(n-1) after n-th failed attempt, and at most given max if max is larger than 0.
+func Exponential(base, max time.Duration) Policy {
+	return From(func(n int, _ time.Duration) time.Duration {
+		return exponential(base, max, n)
+	})
+}
+
+// FullJitter returns a Policy waiting a random delay between 0 and exponential delay from given base and max.
+func FullJitter(base, max time.Duration) Policy {
+	return fromJitter(func(r Random, n int, _ time.Duration) time.Duration {
+		return between(r, 0, exponential(base, max, n))
+	})
+}
+
+// DecorrelatedJitter returns a Policy waiting a random delay between given base and three times previous delay, and at most given max if max is larger than 0.
+// Three times previous delay saturates at math.MaxInt64. All delays are 0 if base is not larger than 0.
+func DecorrelatedJitter(base, max time.Duration) Policy {
+	return fromJitter(func(r Random, _ int, prev time.Duration) time.Duration {
+		upper := funcs.Cond(prev > math.MaxInt64/3, time.Duration(math.MaxInt64), prev*3)
+		d := between(r, base, funcs.Max(base, upper))
+		return funcs.Cond(max > 0 && d > max, max, d)
+	})
+}
+
+// MaxAttempts returns a new Policy trying at most n times. It is unlimited if n is not larger than 0.
+func (p Policy) MaxAttempts(n int) Policy {
+	p.attempts = n
+	return p
+}
+
+// MaxElapsed returns a new Policy not retrying after given duration d from first attempt. It is unlimited if d is not larger than 0.
+func (p Policy) MaxElapsed(d time.Duration) Policy {
+	p.elapsed = d
+	return p
+}
+
+// When returns a new Policy only retrying errors satisfying given function retryable.
+func (p Policy) When(retryable funcs.Predict[error]) Policy {
+	p.retryable = retryable
+	return p
+}
+
+// WithClock returns a new Policy getting time from given clock.
+func (p Policy) WithClock(clock Clock) Policy {
+	p.clock = clock
+	return p
+}
+
+// WithRandom returns a new Policy taking random numbers for jitter from given random.
+func (p Policy) WithRandom(random Random) Policy {
+	p.random = random
+	return p
+}
+
+// next returns delay before next attempt and true if failed attempt n with error err can be retried after start time.
+func (p Policy) next(start time.Time, n int, prev time.Duration, err error) (time.Duration, bool) {
+	if p.attempts > 0 && n >= p.attempts {
+		return 0, false
+	}
+
+	if p.retryable != nil && !p.retryable(err) {
+		return 0, false
+	}
+
+	var d time.Duration
+	if p.backoff != nil {
+		d = p.backoff(p.random, n, prev)
+	}
+
+	if p.elapsed > 0 && p.clock.Now().Add(d).Sub(start) > p.elapsed {
+		return 0, false
+	}
+	return d, true
+}
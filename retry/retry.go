@@ -0,0 +1,65 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/future"
+	"github.com/dairaga/gs/try"
+)
+
+// Try applies given function op until it succeeds or given policy p stops retrying.
+// It returns the last Failure from op if p stops retrying, or Failure with error from ctx if ctx is done.
+func Try[T any](ctx context.Context, p Policy, op func() gs.Try[T]) gs.Try[T] {
+	if p.clock == nil {
+		p.clock = SystemClock
+	}
+	if p.random == nil {
+		p.random = SystemRandom
+	}
+	start := p.clock.Now()
+
+	var delay time.Duration
+	for n := 1; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return gs.Failure[T](err)
+		}
+
+		result := op()
+		if result.IsSuccess() {
+			return result
+		}
+
+		next, ok := p.next(start, n, delay, result.Failed())
+		if !ok {
+			return result
+		}
+		delay = next
+
+		select {
+		case <-ctx.Done():
+			return gs.Failure[T](ctx.Err())
+		case <-p.clock.After(delay):
+		}
+	}
+}
+
+// Do applies given function op until it returns nil error or given policy p stops retrying.
+func Do[T any](ctx context.Context, p Policy, op func() (T, error)) gs.Try[T] {
+	return Try(ctx, p, func() gs.Try[T] {
+		return try.From(op())
+	})
+}
+
+// Future returns a Future waiting for the result of retrying given function op with given policy p.
+func Future[T any](ctx context.Context, p Policy, op func() gs.Try[T]) gs.Future[T] {
+	return future.Try(ctx, func() (T, error) {
+		return Try(ctx, p, op).Fetch()
+	})
+}
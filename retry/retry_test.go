@@ -0,0 +1,262 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+var errRetry = errors.New("retry")
+
+// fakeClock advances immediately when waiting, and records all delays.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// maxRandom always returns the largest random number.
+type maxRandom struct{}
+
+func (maxRandom) Int63n(n int64) int64 {
+	return n - 1
+}
+
+// failN returns a function failing n times before success.
+func failN(n int) (func() (int, error), *int) {
+	count := 0
+	return func() (int, error) {
+		count++
+		if count <= n {
+			return 0, errRetry
+		}
+		return count, nil
+	}, &count
+}
+
+func assertTry[T any](t *testing.T, a, b gs.Try[T]) {
+	t.Helper()
+	assert.Equal(t, a.IsSuccess(), b.IsSuccess())
+
+	aval, aerr := a.Fetch()
+	bval, berr := b.Fetch()
+
+	assert.Equal(t, aval, bval)
+	assert.True(t, errors.Is(aerr, berr))
+}
+
+func TestConstant(t *testing.T) {
+	clock := &fakeClock{}
+	op, count := failN(3)
+
+	p := retry.Constant(time.Second).WithClock(clock)
+	assertTry(t, gs.Success(4), retry.Do(context.Background(), p, op))
+	assert.Equal(t, 4, *count)
+	assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, clock.delays)
+}
+
+func TestExponential(t *testing.T) {
+	clock := &fakeClock{}
+	op, _ := failN(5)
+
+	p := retry.Exponential(time.Second, 10*time.Second).WithClock(clock)
+	assertTry(t, gs.Success(6), retry.Do(context.Background(), p, op))
+	assert.Equal(t,
+		[]time.Duration{
+			time.Second,
+			2 * time.Second,
+			4 * time.Second,
+			8 * time.Second,
+			10 * time.Second,
+		},
+		clock.delays,
+	)
+}
+
+func TestExponentialOverflow(t *testing.T) {
+	clock := &fakeClock{}
+	op, _ := failN(100)
+
+	p := retry.Exponential(3*time.Second, 0).WithClock(clock)
+	assertTry(t, gs.Success(101), retry.Do(context.Background(), p, op))
+	assert.Len(t, clock.delays, 100)
+	for i := 1; i < len(clock.delays); i++ {
+		assert.LessOrEqual(t, clock.delays[i-1], clock.delays[i])
+	}
+	assert.Equal(t, time.Duration(math.MaxInt64), clock.delays[99])
+
+	clock = &fakeClock{}
+	op, _ = failN(100)
+	p = retry.Exponential(3*time.Second, time.Hour).WithClock(clock)
+	assertTry(t, gs.Success(101), retry.Do(context.Background(), p, op))
+	assert.Equal(t, time.Hour, clock.delays[99])
+}
+
+func TestJitter(t *testing.T) {
+	clock := &fakeClock{}
+	op, _ := failN(10)
+
+	p := retry.FullJitter(time.Second, 8*time.Second).WithClock(clock)
+	assertTry(t, gs.Success(11), retry.Do(context.Background(), p, op))
+	for i, d := range clock.delays {
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.Less(t, d, time.Second<<i)
+		assert.LessOrEqual(t, d, 8*time.Second)
+	}
+
+	clock = &fakeClock{}
+	op, _ = failN(10)
+	p = retry.DecorrelatedJitter(time.Second, 8*time.Second).WithClock(clock)
+	assertTry(t, gs.Success(11), retry.Do(context.Background(), p, op))
+	prev := time.Second
+	for _, d := range clock.delays {
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, 8*time.Second)
+		assert.LessOrEqual(t, d, prev*3)
+		prev = d
+	}
+}
+
+func TestJitterRandom(t *testing.T) {
+	delays := func(p retry.Policy) []time.Duration {
+		clock := &fakeClock{}
+		op, _ := failN(20)
+		assertTry(t, gs.Success(21), retry.Do(context.Background(), p.WithClock(clock), op))
+		return clock.delays
+	}
+
+	for _, p := range []retry.Policy{
+		retry.FullJitter(time.Second, time.Minute),
+		retry.DecorrelatedJitter(time.Second, time.Minute),
+	} {
+		assert.Equal(t,
+			delays(p.WithRandom(rand.New(rand.NewSource(1)))),
+			delays(p.WithRandom(rand.New(rand.NewSource(1)))),
+		)
+	}
+
+	clock := &fakeClock{}
+	op, _ := failN(100)
+	p := retry.DecorrelatedJitter(time.Second, 0).WithClock(clock).WithRandom(maxRandom{})
+	assertTry(t, gs.Success(101), retry.Do(context.Background(), p, op))
+	assert.Equal(t, 3*time.Second-1, clock.delays[1])
+	for i := 1; i < len(clock.delays); i++ {
+		assert.LessOrEqual(t, clock.delays[i-1], clock.delays[i])
+	}
+	assert.Equal(t, time.Duration(math.MaxInt64-1), clock.delays[99])
+
+	clock = &fakeClock{}
+	op, _ = failN(5)
+	p = retry.DecorrelatedJitter(0, 0).WithClock(clock)
+	assertTry(t, gs.Success(6), retry.Do(context.Background(), p, op))
+	assert.Equal(t, make([]time.Duration, 5), clock.delays)
+}
+
+func TestMaxAttempts(t *testing.T) {
+	clock := &fakeClock{}
+	op, count := failN(10)
+
+	p := retry.Constant(time.Second).MaxAttempts(3).WithClock(clock)
+	assertTry(t, gs.Failure[int](errRetry), retry.Do(context.Background(), p, op))
+	assert.Equal(t, 3, *count)
+	assert.Equal(t, 2, len(clock.delays))
+}
+
+func TestMaxElapsed(t *testing.T) {
+	clock := &fakeClock{}
+	op, count := failN(10)
+
+	p := retry.Exponential(time.Second, 0).MaxElapsed(10 * time.Second).WithClock(clock)
+	assertTry(t, gs.Failure[int](errRetry), retry.Do(context.Background(), p, op))
+	assert.Equal(t, 4, *count)
+	assert.Equal(t,
+		[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		clock.delays,
+	)
+}
+
+func TestWhen(t *testing.T) {
+	clock := &fakeClock{}
+	count := 0
+	op := func() gs.Try[int] {
+		count++
+		if count < 3 {
+			return gs.Failure[int](errRetry)
+		}
+		return gs.Failure[int](gs.ErrUnsupported)
+	}
+
+	p := retry.Constant(time.Second).
+		When(func(err error) bool { return errors.Is(err, errRetry) }).
+		WithClock(clock)
+
+	assertTry(t, gs.Failure[int](gs.ErrUnsupported), retry.Try(context.Background(), p, op))
+	assert.Equal(t, 3, count)
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	op, count := failN(10)
+	assertTry(t,
+		gs.Failure[int](context.Canceled),
+		retry.Do(ctx, retry.Constant(time.Second), op),
+	)
+	assert.Equal(t, 0, *count)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	op, _ = failN(10)
+	assertTry(t,
+		gs.Failure[int](context.DeadlineExceeded),
+		retry.Do(ctx, retry.Constant(time.Hour), op),
+	)
+}
+
+func TestFuture(t *testing.T) {
+	clock := &fakeClock{}
+	op, _ := failN(2)
+
+	f := retry.Future(
+		context.Background(),
+		retry.Constant(time.Second).WithClock(clock),
+		func() gs.Try[int] {
+			v, err := op()
+			if err != nil {
+				return gs.Failure[int](err)
+			}
+			return gs.Success(v)
+		},
+	)
+	assertTry(t, gs.Success(3), f.Wait())
+}
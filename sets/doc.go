@@ -0,0 +1,9 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+/*
+Package sets implements a set of comparable elements built on map.
+*/
+package sets
@@ -0,0 +1,200 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package sets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/slices"
+)
+
+// IsEmpty returns true if s has no element.
+func (s Set[T]) IsEmpty() bool {
+	return len(s) <= 0
+}
+
+// Clone returns a copy of s.
+func (s Set[T]) Clone() Set[T] {
+	return Empty[T]().Merge(s)
+}
+
+// Add adds given elements into s.
+func (s Set[T]) Add(a ...T) Set[T] {
+	for i := range a {
+		s[a[i]] = gs.N()
+	}
+	return s
+}
+
+// Remove removes given elements from s.
+func (s Set[T]) Remove(a ...T) Set[T] {
+	for i := range a {
+		delete(s, a[i])
+	}
+	return s
+}
+
+// Merge adds all elements of another set a into s.
+func (s Set[T]) Merge(a Set[T]) Set[T] {
+	for x := range a {
+		s[x] = gs.N()
+	}
+	return s
+}
+
+// Contain returns true if s has given element x.
+func (s Set[T]) Contain(x T) (ok bool) {
+	_, ok = s[x]
+	return
+}
+
+// Union returns a new set containing elements in s or a.
+func (s Set[T]) Union(a Set[T]) Set[T] {
+	return s.Clone().Merge(a)
+}
+
+// Intersect returns a new set containing elements in both s and a.
+func (s Set[T]) Intersect(a Set[T]) Set[T] {
+	if len(a) < len(s) {
+		return a.Filter(s.Contain)
+	}
+	return s.Filter(a.Contain)
+}
+
+// Diff returns a new set containing elements in s but not in a.
+func (s Set[T]) Diff(a Set[T]) Set[T] {
+	return s.FilterNot(a.Contain)
+}
+
+// SymmetricDiff returns a new set containing elements in either s or a but not in both.
+func (s Set[T]) SymmetricDiff(a Set[T]) Set[T] {
+	return s.Diff(a).Merge(a.Diff(s))
+}
+
+// SubsetOf returns true if all elements of s are in a.
+func (s Set[T]) SubsetOf(a Set[T]) bool {
+	return len(s) <= len(a) && s.Forall(a.Contain)
+}
+
+// Equal returns true if s and a have the same elements.
+func (s Set[T]) Equal(a Set[T]) bool {
+	return len(s) == len(a) && s.SubsetOf(a)
+}
+
+// Count returns numbers of elements in s satisfying given function p.
+func (s Set[T]) Count(p funcs.Predict[T]) int {
+	return Fold(s, 0, func(z int, x T) int {
+		return funcs.Cond(p(x), z+1, z)
+	})
+}
+
+// Exists returns true if at least one element in s satisfies given function p.
+func (s Set[T]) Exists(p funcs.Predict[T]) bool {
+	for x := range s {
+		if p(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// Forall returns true if s is empty or all elements satisfy given function p.
+func (s Set[T]) Forall(p funcs.Predict[T]) bool {
+	for x := range s {
+		if !p(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// Foreach applies given function op to each element in s.
+func (s Set[T]) Foreach(op func(T)) {
+	for x := range s {
+		op(x)
+	}
+}
+
+// Filter returns a new set made of elements in s satisfying given function p.
+func (s Set[T]) Filter(p funcs.Predict[T]) Set[T] {
+	return Fold(s, Empty[T](), func(z Set[T], x T) Set[T] {
+		if p(x) {
+			z[x] = gs.N()
+		}
+		return z
+	})
+}
+
+// FilterNot returns a new set made of elements in s not satisfying given function p.
+func (s Set[T]) FilterNot(p funcs.Predict[T]) Set[T] {
+	return s.Filter(func(x T) bool { return !p(x) })
+}
+
+// Partition partitions s into two sets according to given function p. The first set made of elements in s not satisfying the function p, and the second set made of elements satisfying the function p.
+func (s Set[T]) Partition(p funcs.Predict[T]) (_, _ Set[T]) {
+	t2 := Fold(
+		s,
+		gs.T2(Empty[T](), Empty[T]()),
+		func(z gs.Tuple2[Set[T], Set[T]], x T) gs.Tuple2[Set[T], Set[T]] {
+			if p(x) {
+				z.V2[x] = gs.N()
+			} else {
+				z.V1[x] = gs.N()
+			}
+			return z
+		},
+	)
+	return t2.V1, t2.V2
+}
+
+// Slice returns a slice containing all elements of s. The order of elements is not specified.
+func (s Set[T]) Slice() slices.S[T] {
+	return Fold(s, make(slices.S[T], 0, len(s)), func(z slices.S[T], x T) slices.S[T] {
+		return append(z, x)
+	})
+}
+
+// Sorted returns a slice containing all elements of s sorted with given cmp.
+func (s Set[T]) Sorted(cmp funcs.Ordering[T, T]) slices.S[T] {
+	return s.Slice().Sort(cmp)
+}
+
+// sorted returns a slice containing all elements of s. Numbers and strings are in ascending order, and order of other elements is not specified.
+func (s Set[T]) sorted() slices.S[T] {
+	return s.Sorted(func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// String returns elements of s in ascending order if they are numbers or strings. Order of other elements is not specified, and Sorted gives a fixed order.
+func (s Set[T]) String() string {
+	return fmt.Sprintf(`Set(%s)`, strings.Join(slices.Map(s.sorted(), func(x T) string { return fmt.Sprint(x) }), ", "))
+}
+
+// MarshalJSON encodes s to a JSON array in the same order as String.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]T(s.sorted()))
+}
+
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var a []T
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*s = FromSlice(a)
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package sets_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/maps"
+	"github.com/dairaga/gs/sets"
+	"github.com/dairaga/gs/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	even = func(v int) bool { return (v & 0x01) == 0 }
+)
+
+func TestFrom(t *testing.T) {
+	s := sets.From(1, 2, 2, 3)
+	assert.Equal(t, 3, len(s))
+	assert.True(t, s.Contain(2))
+	assert.False(t, s.Contain(4))
+
+	assert.Equal(t, s, sets.FromSlice(slices.From(3, 2, 1)))
+	assert.Equal(t, s, sets.FromKeys(maps.From(maps.P(1, "1"), maps.P(2, "2"), maps.P(3, "3"))))
+	assert.True(t, sets.Empty[int]().IsEmpty())
+}
+
+func TestAddRemove(t *testing.T) {
+	s := sets.Empty[int]().Add(1, 2, 3)
+	assert.Equal(t, sets.From(1, 2, 3), s)
+
+	s.Remove(2, 4)
+	assert.Equal(t, sets.From(1, 3), s)
+
+	c := s.Clone().Add(5)
+	assert.Equal(t, sets.From(1, 3), s)
+	assert.Equal(t, sets.From(1, 3, 5), c)
+}
+
+func TestSetOperations(t *testing.T) {
+	a := sets.From(1, 2, 3, 4)
+	b := sets.From(3, 4, 5)
+
+	assert.Equal(t, sets.From(1, 2, 3, 4, 5), a.Union(b))
+	assert.Equal(t, sets.From(3, 4), a.Intersect(b))
+	assert.Equal(t, sets.From(3, 4), b.Intersect(a))
+	assert.Equal(t, sets.From(1, 2), a.Diff(b))
+	assert.Equal(t, sets.From(5), b.Diff(a))
+	assert.Equal(t, sets.From(1, 2, 5), a.SymmetricDiff(b))
+	assert.Equal(t, sets.From(1, 2, 3, 4), a)
+
+	assert.True(t, sets.From(3, 4).SubsetOf(a))
+	assert.True(t, sets.Empty[int]().SubsetOf(a))
+	assert.False(t, b.SubsetOf(a))
+	assert.True(t, a.Equal(sets.From(4, 3, 2, 1)))
+	assert.False(t, a.Equal(b))
+}
+
+func TestSetPredicates(t *testing.T) {
+	s := sets.From(1, 2, 3, 4)
+
+	assert.Equal(t, 2, s.Count(even))
+	assert.True(t, s.Exists(even))
+	assert.False(t, sets.From(1, 3).Exists(even))
+	assert.True(t, sets.From(2, 4).Forall(even))
+	assert.False(t, s.Forall(even))
+
+	sum := 0
+	s.Foreach(func(v int) { sum += v })
+	assert.Equal(t, 10, sum)
+
+	assert.Equal(t, sets.From(2, 4), s.Filter(even))
+	assert.Equal(t, sets.From(1, 3), s.FilterNot(even))
+
+	a, b := s.Partition(even)
+	assert.Equal(t, sets.From(1, 3), a)
+	assert.Equal(t, sets.From(2, 4), b)
+}
+
+func TestMap(t *testing.T) {
+	assert.Equal(t,
+		sets.From("1", "2"),
+		sets.Map(sets.From(1, 2), strconv.Itoa),
+	)
+
+	assert.Equal(t,
+		sets.From(0, 1, 2),
+		sets.Map(sets.From(1, 2, 3, 4), func(v int) int { return v / 2 }),
+	)
+
+	assert.Equal(t,
+		sets.From(1, 2, 3),
+		sets.FlatMap(sets.From(1, 2), func(v int) sets.Set[int] { return sets.From(v, v+1) }),
+	)
+}
+
+func TestSlice(t *testing.T) {
+	s := sets.From(3, 1, 2)
+	assert.ElementsMatch(t, slices.From(1, 2, 3), s.Slice())
+	assert.Equal(t, slices.From(3, 2, 1), s.Sorted(funcs.Reverse(funcs.Order[int])))
+
+	type point struct{ x, y int }
+	ps := sets.From(point{10, 0}, point{9, 1}, point{1, 2})
+	assert.Equal(t,
+		slices.From(point{1, 2}, point{9, 1}, point{10, 0}),
+		ps.Sorted(func(a, b point) int { return funcs.Order(a.x, b.x) }),
+	)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "Set(1, 2, 10)", sets.From(10, 2, 1).String())
+	assert.Equal(t, "Set(a, b)", sets.From("b", "a").String())
+	assert.Equal(t, "Set()", sets.Empty[int]().String())
+	assert.Equal(t, "Set({1 2})", sets.From(struct{ x, y int }{1, 2}).String())
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(sets.From(3, 1, 2))
+	assert.Nil(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+
+	data, err = json.Marshal(sets.Empty[int]())
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(data))
+
+	var s sets.Set[string]
+	assert.Nil(t, json.Unmarshal([]byte(`["a","b","a"]`), &s))
+	assert.Equal(t, sets.From("a", "b"), s)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":1}`), &s))
+}
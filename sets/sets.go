@@ -0,0 +1,89 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package sets
+
+import (
+	"reflect"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/maps"
+	"github.com/dairaga/gs/slices"
+)
+
+// Set is a collection of distinct elements.
+type Set[T comparable] map[T]gs.Nothing
+
+// Empty returns an empty set.
+func Empty[T comparable]() Set[T] {
+	return make(Set[T])
+}
+
+// From returns a set containing given elements.
+func From[T comparable](a ...T) Set[T] {
+	return FromSlice(a)
+}
+
+// FromSlice returns a set containing elements of given slice s.
+func FromSlice[T comparable](s slices.S[T]) Set[T] {
+	ret := make(Set[T], len(s))
+	for i := range s {
+		ret[s[i]] = gs.N()
+	}
+	return ret
+}
+
+// FromKeys returns a set containing keys of given map m.
+func FromKeys[K comparable, V any](m maps.M[K, V]) Set[K] {
+	return FromSlice(m.Keys())
+}
+
+// less orders numbers and strings by value. Other elements are never less than each other, so that their order is not specified.
+func less[T comparable](a, b T) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Kind() != vb.Kind() {
+		return false
+	}
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return va.Int() < vb.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return va.Uint() < vb.Uint()
+	case reflect.Float32, reflect.Float64:
+		return va.Float() < vb.Float()
+	case reflect.String:
+		return va.String() < vb.String()
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// Fold applies given function op to a start value z and all elements of set s.
+func Fold[T comparable, U any](s Set[T], z U, op func(U, T) U) (ret U) {
+	ret = z
+	for x := range s {
+		ret = op(ret, x)
+	}
+	return
+}
+
+// Map returns a new set by applying given function op to all elements of set s.
+func Map[T, U comparable](s Set[T], op funcs.Func[T, U]) Set[U] {
+	return Fold(s, make(Set[U], len(s)), func(z Set[U], x T) Set[U] {
+		return z.Add(op(x))
+	})
+}
+
+// FlatMap returns a new set by applying given function op to all elements of set s and merging results.
+func FlatMap[T, U comparable](s Set[T], op funcs.Func[T, Set[U]]) Set[U] {
+	return Fold(s, Empty[U](), func(z Set[U], x T) Set[U] {
+		return z.Merge(op(x))
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package slices
+
+import (
+	"math"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+)
+
+// kahan is an accumulator of compensated (Kahan-Babuska) summation.
+type kahan struct {
+	_   struct{}
+	sum float64
+	c   float64
+}
+
+func (k *kahan) add(x float64) {
+	t := k.sum + x
+	if math.Abs(k.sum) >= math.Abs(x) {
+		k.c += (k.sum - t) + x
+	} else {
+		k.c += (x - t) + k.sum
+	}
+	k.sum = t
+}
+
+func (k *kahan) result() float64 {
+	return k.sum + k.c
+}
+
+// isFloat returns true if T is a floating-point type.
+func isFloat[T gs.Numeric]() bool {
+	return T(1)/T(2) != 0
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// SumBy returns sum of results of applying given function op to all elements of s. Floating-point results are summed with compensated summation.
+func SumBy[T any, N gs.Numeric](s S[T], op funcs.Func[T, N]) N {
+	if !isFloat[N]() {
+		var ret N
+		for i := range s {
+			ret += op(s[i])
+		}
+		return ret
+	}
+
+	var k kahan
+	for i := range s {
+		k.add(float64(op(s[i])))
+	}
+	return N(k.result())
+}
+
+// Sum returns sum of all elements of s, or returns 0 if s is empty. Floating-point elements are summed with compensated summation.
+func Sum[T gs.Numeric](s S[T]) T {
+	return SumBy(s, funcs.Self[T])
+}
+
+// Product returns product of all elements of s, or returns 1 if s is empty.
+func Product[T gs.Numeric](s S[T]) T {
+	return Fold(s, T(1), func(z, x T) T { return z * x })
+}
+
+// MeanBy returns Some with arithmetic mean of results of applying given function op to all elements of s, or returns None if s is empty.
+func MeanBy[T any, N gs.Numeric](s S[T], op funcs.Func[T, N]) gs.Option[float64] {
+	if len(s) <= 0 {
+		return gs.None[float64]()
+	}
+
+	var k kahan
+	for i := range s {
+		k.add(float64(op(s[i])))
+	}
+	return gs.Some(k.result() / float64(len(s)))
+}
+
+// Mean returns Some with arithmetic mean of s, or returns None if s is empty.
+func Mean[T gs.Numeric](s S[T]) gs.Option[float64] {
+	return MeanBy(s, funcs.Self[T])
+}
+
+// Variance returns Some with population variance of s, or returns None if s is empty.
+func Variance[T gs.Numeric](s S[T]) gs.Option[float64] {
+	if len(s) <= 0 {
+		return gs.None[float64]()
+	}
+
+	// Welford's online algorithm
+	mean, m2 := 0.0, 0.0
+	for i := range s {
+		x := float64(s[i])
+		d := x - mean
+		mean += d / float64(i+1)
+		m2 += d * (x - mean)
+	}
+	return gs.Some(m2 / float64(len(s)))
+}
+
+// StdDev returns Some with population standard deviation of s, or returns None if s is empty.
+func StdDev[T gs.Numeric](s S[T]) gs.Option[float64] {
+	v := Variance(s)
+	if v.IsEmpty() {
+		return v
+	}
+	return gs.Some(math.Sqrt(v.Get()))
+}
+
+// Percentile returns Some with given p-th percentile of s with linear interpolation between closest ranks.
+// It returns None if s is empty or p is not in [0, 100], including NaN.
+func Percentile[T gs.Numeric](s S[T], p float64) gs.Option[float64] {
+	if len(s) <= 0 || math.IsNaN(p) || p < 0 || p > 100 {
+		return gs.None[float64]()
+	}
+
+	sorted := s.Clone().Sort(funcs.Order[T])
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return gs.Some(float64(sorted[lo]) + (rank-float64(lo))*(float64(sorted[hi])-float64(sorted[lo])))
+}
+
+// Median returns Some with median of s, or returns None if s is empty.
+func Median[T gs.Numeric](s S[T]) gs.Option[float64] {
+	return Percentile(s, 50)
+}
+
+// Histogram counts elements of s in buckets split by given sorted bounds.
+// The result has len(bounds)+1 counts. The first count is elements less than bounds[0],
+// the i-th count is elements in [bounds[i-1], bounds[i]), and the last count is elements not less than the last bound.
+func Histogram[T gs.Numeric](s S[T], bounds S[T]) S[int] {
+	ret := make(S[int], len(bounds)+1)
+	for i := range s {
+		ret[upper(bounds, s[i], funcs.Order[T])]++
+	}
+	return ret
+}
+
+// MinMax returns Some with minimum and maximum of s in one pass, or returns None if s is empty.
+func MinMax[T gs.Numeric](s S[T]) gs.Option[gs.Tuple2[T, T]] {
+	if len(s) <= 0 {
+		return gs.None[gs.Tuple2[T, T]]()
+	}
+
+	ret := gs.T2(s[0], s[0])
+	for i := 1; i < len(s); i++ {
+		ret.V1 = funcs.Min(ret.V1, s[i])
+		ret.V2 = funcs.Max(ret.V2, s[i])
+	}
+	return gs.Some(ret)
+}
@@ -0,0 +1,205 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package slices
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+)
+
+// Executor runs tasks of parallel operations. Any future.Executor is an Executor.
+type Executor interface {
+	// Execute runs given task, and returns an error if the task is rejected.
+	Execute(task func()) error
+}
+
+type level chan struct{}
+
+// Execute runs given task in a new goroutine after one of running tasks finishes.
+func (l level) Execute(task func()) error {
+	l <- struct{}{}
+	go func() {
+		defer func() { <-l }()
+		task()
+	}()
+	return nil
+}
+
+// Level returns an Executor running at most n tasks at the same time.
+func Level(n int) Executor {
+	if n <= 0 {
+		n = 1
+	}
+	return make(level, n)
+}
+
+// parallel splits indexes from 0 to n into chunks, and runs op with index of chunk and each index in the chunk on exec.
+// It stops when ctx is done or any op panics, and returns the first error.
+func parallel(ctx context.Context, exec Executor, n int, op func(c, i int)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		err  error
+	)
+
+	fail := func(e error) {
+		once.Do(func() {
+			err = e
+			cancel()
+		})
+	}
+
+	size := (n + chunks(n) - 1) / chunks(n)
+	for c, lo := 0, 0; lo < n && ctx.Err() == nil; c, lo = c+1, lo+size {
+		c, lo, hi := c, lo, lo+size
+		if hi > n {
+			hi = n
+		}
+
+		wg.Add(1)
+		task := func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					fail(&gs.PanicError{Value: r, Stack: debug.Stack()})
+				}
+			}()
+
+			for i := lo; i < hi && ctx.Err() == nil; i++ {
+				op(c, i)
+			}
+		}
+
+		if e := exec.Execute(task); e != nil {
+			wg.Done()
+			fail(e)
+		}
+	}
+
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// chunks returns number of chunks for n elements.
+func chunks(n int) int {
+	ret := runtime.GOMAXPROCS(0) * 4
+	if n < ret {
+		ret = n
+	}
+	if ret <= 0 {
+		ret = 1
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// ParMap returns a new slice by applying op to all elements of s in parallel on exec.
+// The results keep the order of s. It returns an error if ctx is done, a task is rejected, or op panics.
+func ParMap[T, U any](ctx context.Context, exec Executor, s S[T], op funcs.Func[T, U]) (S[U], error) {
+	ret := make(S[U], len(s))
+	err := parallel(ctx, exec, len(s), func(_, i int) {
+		ret[i] = op(s[i])
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ParFilter returns a new slice with all elements of s satisfying p tested in parallel on exec.
+func ParFilter[T any](ctx context.Context, exec Executor, s S[T], p funcs.Predict[T]) (S[T], error) {
+	ok, err := ParMap(ctx, exec, s, funcs.Func[T, bool](p))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := Empty[T]()
+	for i := range s {
+		if ok[i] {
+			ret = append(ret, s[i])
+		}
+	}
+	return ret, nil
+}
+
+// ParFlatMap returns a new slice by applying op to all elements of s in parallel on exec and concatenating the results.
+func ParFlatMap[T, U any](ctx context.Context, exec Executor, s S[T], op funcs.Func[T, S[U]]) (S[U], error) {
+	parts, err := ParMap(ctx, exec, s, op)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := Empty[U]()
+	for i := range parts {
+		ret = append(ret, parts[i]...)
+	}
+	return ret, nil
+}
+
+// ParForeach applies op to all elements of s in parallel on exec.
+func ParForeach[T any](ctx context.Context, exec Executor, s S[T], op func(T)) error {
+	return parallel(ctx, exec, len(s), func(_, i int) {
+		op(s[i])
+	})
+}
+
+// ParReduce reduces all elements of s in parallel on exec with given associative op.
+// It returns None if s is empty.
+func ParReduce[T any](ctx context.Context, exec Executor, s S[T], op func(T, T) T) (gs.Option[T], error) {
+	parts := make(S[gs.Option[T]], chunks(len(s)))
+	err := parallel(ctx, exec, len(s), func(c, i int) {
+		if parts[c] == nil {
+			parts[c] = gs.Some(s[i])
+		} else {
+			parts[c] = gs.Some(op(parts[c].Get(), s[i]))
+		}
+	})
+
+	if err != nil {
+		return gs.None[T](), err
+	}
+
+	return Fold(parts, gs.None[T](), func(z, x gs.Option[T]) gs.Option[T] {
+		switch {
+		case x == nil:
+			return z
+		case z.IsEmpty():
+			return x
+		default:
+			return gs.Some(op(z.Get(), x.Get()))
+		}
+	}), nil
+}
+
+// ParGroupBy partitions s into a map of slices according to the discriminator function key computed in parallel on exec.
+// Elements in each slice keep the order of s.
+func ParGroupBy[T any, K comparable](ctx context.Context, exec Executor, s S[T], key funcs.Func[T, K]) (map[K]S[T], error) {
+	keys, err := ParMap(ctx, exec, s, key)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[K]S[T])
+	for i := range s {
+		ret[keys[i]] = append(ret[keys[i]], s[i])
+	}
+	return ret, nil
+}
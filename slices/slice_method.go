@@ -348,3 +348,44 @@ func (s S[T]) Sort(cmp funcs.Ordering[T, T]) S[T] {
 	sort.SliceStable(s, func(i, j int) bool { return cmp(s[i], s[j]) < 0 })
 	return s
 }
+
+// Sliding returns windows of given size and starting every step elements of this.
+// Windows are produced until the last element is covered, so the last window may be shorter than size.
+// Elements are skipped between windows if step is larger than size.
+// It panics with gs.ErrUnsupported if size or step is not positive.
+//
+// The result is []S[T] instead of S[S[T]], because a method of S[T] returning S[S[T]] is an instantiation cycle.
+func (s S[T]) Sliding(size, step int) []S[T] {
+	if size <= 0 || step <= 0 {
+		panic(gs.ErrUnsupported)
+	}
+
+	ret := []S[T]{}
+	for lo := 0; lo < len(s); lo += step {
+		hi := lo + size
+		if hi >= len(s) {
+			return append(ret, s[lo:].Clone())
+		}
+		ret = append(ret, s[lo:hi].Clone())
+	}
+	return ret
+}
+
+// Grouped partitions this into slices of given size n. The last slice may be shorter than n.
+// It panics with gs.ErrUnsupported if n is not positive.
+func (s S[T]) Grouped(n int) []S[T] {
+	return s.Sliding(n, n)
+}
+
+// ChunkWhile partitions this into runs of consecutive elements. A run continues while given function p returns true with the previous and current elements.
+func (s S[T]) ChunkWhile(p func(T, T) bool) []S[T] {
+	ret := []S[T]{}
+	lo := 0
+	for i := 1; i <= len(s); i++ {
+		if i == len(s) || !p(s[i-1], s[i]) {
+			ret = append(ret, s[lo:i].Clone())
+			lo = i
+		}
+	}
+	return ret
+}
@@ -6,8 +6,14 @@
 package slices_test
 
 import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"testing/quick"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
@@ -688,3 +694,494 @@ func TestIsEmpty(t *testing.T) {
 	assert.True(t, slices.IsEmpty(slices.Empty[int]()))
 	assert.False(t, slices.IsEmpty(slices.One(0)))
 }
+
+func TestParMap(t *testing.T) {
+	ctx := context.Background()
+	prop := func(s []int, n uint8) bool {
+		par, err := slices.ParMap(ctx, slices.Level(int(n%8)+1), s, strconv.Itoa)
+		return err == nil && slices.Equal(slices.Map(s, strconv.Itoa), par)
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestParFilter(t *testing.T) {
+	ctx := context.Background()
+	prop := func(s []int, n uint8) bool {
+		par, err := slices.ParFilter(ctx, slices.Level(int(n%8)+1), s, even)
+		return err == nil && slices.Equal(slices.S[int](s).Filter(even), par)
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestParFlatMap(t *testing.T) {
+	ctx := context.Background()
+	op := func(v int) slices.S[int] {
+		return slices.Fill(v&0x03, v)
+	}
+	prop := func(s []int, n uint8) bool {
+		par, err := slices.ParFlatMap(ctx, slices.Level(int(n%8)+1), s, op)
+		return err == nil && slices.Equal(slices.FlatMap(s, op), par)
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestParForeach(t *testing.T) {
+	var sum int64
+	err := slices.ParForeach(context.Background(), slices.Level(4), slices.Range(1, 101, 1), func(v int) {
+		atomic.AddInt64(&sum, int64(v))
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5050), sum)
+}
+
+func TestParReduce(t *testing.T) {
+	ctx := context.Background()
+	sum := func(a, b int) int { return a + b }
+	prop := func(s []int, n uint8) bool {
+		par, err := slices.ParReduce(ctx, slices.Level(int(n%8)+1), s, sum)
+		seq := slices.S[int](s).Reduce(sum)
+		return err == nil && par.IsDefined() == seq.IsDefined() && par.GetOrElse(0) == seq.GetOrElse(0)
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+
+	concat := func(a, b string) string { return a + b }
+	s := slices.Map(slices.Range(0, 100, 1), strconv.Itoa)
+	par, err := slices.ParReduce(ctx, slices.Level(4), s, concat)
+	assert.Nil(t, err)
+	assert.Equal(t, s.Reduce(concat).Get(), par.Get())
+}
+
+func TestParGroupBy(t *testing.T) {
+	ctx := context.Background()
+	key := func(v int) int { return v % 5 }
+	prop := func(s []int, n uint8) bool {
+		par, err := slices.ParGroupBy(ctx, slices.Level(int(n%8)+1), s, key)
+		return err == nil && reflect.DeepEqual(slices.GroupBy(s, key), par)
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestParPanic(t *testing.T) {
+	_, err := slices.ParMap(context.Background(), slices.Level(4), slices.Range(0, 100, 1), func(v int) int {
+		if v == 50 {
+			panic("boom")
+		}
+		return v
+	})
+
+	var perr *gs.PanicError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, "boom", perr.Value)
+}
+
+func TestParCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := slices.ParMap(ctx, slices.Level(4), slices.Range(0, 100, 1), funcs.Self[int])
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	var count int64
+	ctx, cancel = context.WithCancel(context.Background())
+	err = slices.ParForeach(ctx, slices.Level(1), slices.Range(0, 1000, 1), func(v int) {
+		if atomic.AddInt64(&count, 1) == 10 {
+			cancel()
+		}
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Less(t, count, int64(1000))
+}
+
+func TestParRejected(t *testing.T) {
+	errRejected := errors.New("rejected")
+	exec := executor(func(func()) error { return errRejected })
+
+	_, err := slices.ParMap(context.Background(), exec, slices.Range(0, 100, 1), funcs.Self[int])
+	assert.True(t, errors.Is(err, errRejected))
+}
+
+type executor func(func()) error
+
+func (e executor) Execute(task func()) error {
+	return e(task)
+}
+
+func TestSliding(t *testing.T) {
+	s := slices.From(1, 2, 3, 4, 5)
+
+	assert.Equal(t,
+		[]slices.S[int]{slices.From(1, 2, 3), slices.From(2, 3, 4), slices.From(3, 4, 5)},
+		s.Sliding(3, 1),
+	)
+
+	// short final window
+	assert.Equal(t,
+		[]slices.S[int]{slices.From(1, 2), slices.From(3, 4), slices.From(5)},
+		s.Sliding(2, 2),
+	)
+
+	// step > size skips elements
+	assert.Equal(t,
+		[]slices.S[int]{slices.From(1, 2), slices.From(5)},
+		s.Sliding(2, 4),
+	)
+	assert.Equal(t,
+		[]slices.S[int]{slices.From(1, 2)},
+		slices.From(1, 2, 3, 4).Sliding(2, 4),
+	)
+
+	// size larger than s
+	assert.Equal(t, []slices.S[int]{s}, s.Sliding(10, 1))
+	assert.Equal(t, []slices.S[int]{}, slices.Empty[int]().Sliding(2, 1))
+
+	windows := s.Sliding(2, 1)
+	windows[0] = append(windows[0], 100)
+	assert.Equal(t, slices.From(2, 3), windows[1])
+	assert.Equal(t, slices.From(1, 2, 3, 4, 5), s)
+
+	assert.Panics(t, func() { s.Sliding(0, 1) })
+	assert.Panics(t, func() { s.Sliding(1, 0) })
+}
+
+func TestGrouped(t *testing.T) {
+	assert.Equal(t,
+		[]slices.S[int]{slices.From(1, 2, 3), slices.From(4, 5, 6), slices.From(7)},
+		slices.Range(1, 8, 1).Grouped(3),
+	)
+	assert.Equal(t, []slices.S[int]{}, slices.Empty[int]().Grouped(3))
+	assert.Panics(t, func() { slices.One(1).Grouped(0) })
+}
+
+func TestChunkBy(t *testing.T) {
+	s := slices.From(1, 3, 2, 4, 6, 5, 8)
+	assert.Equal(t,
+		slices.From(slices.From(1, 3), slices.From(2, 4, 6), slices.From(5), slices.From(8)),
+		slices.ChunkBy(s, even),
+	)
+	assert.Equal(t, slices.Empty[slices.S[int]](), slices.ChunkBy(slices.Empty[int](), even))
+
+	assert.Equal(t,
+		[]slices.S[int]{slices.From(1, 3), slices.From(2, 4, 6), slices.From(5, 8)},
+		s.ChunkWhile(func(a, b int) bool { return a < b }),
+	)
+	assert.Equal(t, []slices.S[int]{}, slices.Empty[int]().ChunkWhile(func(a, b int) bool { return true }))
+}
+
+func TestZip(t *testing.T) {
+	assert.Equal(t,
+		slices.From(gs.T2(1, "a"), gs.T2(2, "b")),
+		slices.Zip(slices.From(1, 2, 3), slices.From("a", "b")),
+	)
+	assert.Equal(t,
+		slices.Empty[gs.Tuple2[int, string]](),
+		slices.Zip(slices.Empty[int](), slices.From("a")),
+	)
+}
+
+func TestZipAll(t *testing.T) {
+	a := slices.From(1, 2, 3)
+	b := slices.From("a")
+
+	assert.Equal(t,
+		slices.From(gs.T2(1, "a"), gs.T2(2, "z"), gs.T2(3, "z")),
+		slices.ZipAll(a, b, 0, "z"),
+	)
+	assert.Equal(t,
+		slices.From(gs.T2("a", 1), gs.T2("z", 2), gs.T2("z", 3)),
+		slices.ZipAll(b, a, "z", 0),
+	)
+	assert.Equal(t, slices.From(1, 2, 3), a)
+	assert.Equal(t, slices.From("a"), b)
+}
+
+func TestZipWithIndex(t *testing.T) {
+	assert.Equal(t,
+		slices.From(gs.T2("a", 0), gs.T2("b", 1)),
+		slices.ZipWithIndex(slices.From("a", "b")),
+	)
+}
+
+func TestZipWith(t *testing.T) {
+	assert.Equal(t,
+		slices.From(11, 22),
+		slices.ZipWith(slices.From(1, 2, 3), slices.From(10, 20), func(a, b int) int { return a + b }),
+	)
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := slices.Unzip(slices.Zip(slices.From(1, 2), slices.From("a", "b")))
+	assert.Equal(t, slices.From(1, 2), a)
+	assert.Equal(t, slices.From("a", "b"), b)
+
+	x, y, z := slices.Unzip3(slices.From(gs.T3(1, "a", true), gs.T3(2, "b", false)))
+	assert.Equal(t, slices.From(1, 2), x)
+	assert.Equal(t, slices.From("a", "b"), y)
+	assert.Equal(t, slices.From(true, false), z)
+}
+
+func TestDistinct(t *testing.T) {
+	assert.Equal(t, slices.From(3, 1, 2), slices.Distinct(slices.From(3, 1, 3, 2, 1)))
+	assert.Equal(t, slices.Empty[int](), slices.Distinct(slices.Empty[int]()))
+
+	people := slices.From(person{age: 3}, person{age: 1}, person{age: 3})
+	assert.Equal(t, people.Take(2), slices.DistinctBy(people, orderize))
+
+	type pair struct {
+		s slices.S[int]
+	}
+	eq := func(a, b pair) bool { return slices.Equal(a.s, b.s) }
+	a, b := pair{s: slices.From(1)}, pair{s: slices.From(2)}
+	assert.Equal(t, slices.From(a, b), slices.DistinctFunc(slices.From(a, b, a, b), eq))
+}
+
+func TestIntersect(t *testing.T) {
+	s := slices.From(1, 2, 3, 2, 1, 2)
+
+	assert.Equal(t, slices.From(1, 2, 2), slices.Intersect(s, slices.From(2, 2, 1, 4)))
+	assert.Equal(t, slices.Empty[int](), slices.Intersect(s, slices.Empty[int]()))
+	assert.Equal(t,
+		slices.From(1, 2, 2),
+		slices.IntersectFunc(s, slices.From("2", "2", "1", "4"), func(a int, b string) bool { return strconv.Itoa(a) == b }),
+	)
+
+	prop := func(a, b []int8) bool {
+		return slices.Equal(slices.Intersect(a, b), slices.IntersectFunc(a, b, funcs.Same[int8]))
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestDiff(t *testing.T) {
+	s := slices.From(1, 2, 3, 2, 1, 2)
+
+	assert.Equal(t, slices.From(3, 1, 2), slices.Diff(s, slices.From(2, 2, 1, 4)))
+	assert.Equal(t, s, slices.Diff(s, slices.Empty[int]()))
+	assert.Equal(t,
+		slices.From(3, 1, 2),
+		slices.DiffFunc(s, slices.From("2", "2", "1", "4"), func(a int, b string) bool { return strconv.Itoa(a) == b }),
+	)
+
+	prop := func(a, b []int8) bool {
+		return slices.Equal(slices.Diff(a, b), slices.DiffFunc(a, b, funcs.Same[int8]))
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestUnion(t *testing.T) {
+	a := slices.From(1, 2)
+	b := slices.From(2, 3)
+
+	assert.Equal(t, slices.From(1, 2, 3), slices.Union(a, b))
+	assert.Equal(t, slices.From(1, 2), a)
+	assert.Equal(t, slices.From(2, 3), b)
+
+	a = slices.From(1, 1, 2, 3)
+	b = slices.From(3, 1, 4, 1, 1, 3)
+	assert.Equal(t, slices.From(1, 1, 2, 3, 4, 1, 3), slices.Union(a, b))
+	assert.Equal(t, slices.From(3, 1, 4, 1, 1, 3, 2), slices.Union(b, a))
+	assert.Equal(t, slices.From(1, 1, 2, 3), slices.Union(a, slices.Empty[int]()))
+	assert.Equal(t, slices.From(1, 1, 2, 3), slices.Union(slices.Empty[int](), a))
+}
+
+func TestOrdering(t *testing.T) {
+	type employee struct {
+		name string
+		age  int
+	}
+	age := func(e employee) int { return e.age }
+	name := func(e employee) string { return e.name }
+
+	s := slices.From(
+		employee{name: "a", age: 30},
+		employee{name: "c", age: 20},
+		employee{name: "b", age: 30},
+		employee{name: "d", age: 20},
+	)
+
+	assert.Equal(t,
+		slices.From(s[3], s[1], s[2], s[0]),
+		s.Clone().Sort(funcs.ThenBy(funcs.By(age), funcs.Reverse(funcs.By(name)))),
+	)
+	assert.Equal(t,
+		slices.From(s[0], s[2], s[1], s[3]),
+		s.Clone().Sort(funcs.Reverse(funcs.By(age))),
+	)
+	assert.Equal(t, s, s.Clone().Sort(funcs.ThenBy(func(employee, employee) int { return 0 })))
+}
+
+func TestLexicographic(t *testing.T) {
+	s := slices.From(slices.From(1, 2), slices.From(1), slices.From(0, 5), slices.Empty[int](), slices.From(1, 1, 9))
+	assert.Equal(t,
+		slices.From(slices.Empty[int](), slices.From(0, 5), slices.From(1), slices.From(1, 1, 9), slices.From(1, 2)),
+		s.Sort(slices.Lexicographic(funcs.Order[int])),
+	)
+
+	tuples := slices.From(gs.T2(2, "a"), gs.T2(1, "b"), gs.T2(2, "b"), gs.T2(1, "a"))
+	assert.Equal(t,
+		slices.From(gs.T2(1, "b"), gs.T2(1, "a"), gs.T2(2, "b"), gs.T2(2, "a")),
+		tuples.Sort(gs.Tuple2Ordering(funcs.Order[int], funcs.Reverse(funcs.Order[string]))),
+	)
+}
+
+func TestByFunc(t *testing.T) {
+	s := slices.From(gs.T2("a", gs.Some(2)), gs.T2("b", gs.None[int]()), gs.T2("c", gs.Some(1)))
+	key := func(x gs.Tuple2[string, gs.Option[int]]) gs.Option[int] { return x.V2 }
+	cmp := func(a, b gs.Option[int]) int {
+		return funcs.Order(a.GetOrElse(0), b.GetOrElse(0))
+	}
+
+	assert.Equal(t, gs.Some(s[0]), slices.MaxByFunc(s, key, cmp))
+	assert.Equal(t, gs.Some(s[1]), slices.MinByFunc(s, key, cmp))
+	assert.Equal(t, slices.From(s[1], s[2], s[0]), slices.SortByFunc(s, key, cmp))
+	assert.False(t, slices.MaxByFunc(slices.Empty[int](), funcs.Self[int], funcs.Order[int]).IsDefined())
+}
+
+func TestSearch(t *testing.T) {
+	s := slices.From(1, 3, 3, 5, 7)
+
+	assert.Equal(t, slices.Found(0), slices.Search(s, 1, funcs.Order[int]))
+	assert.Equal(t, slices.Found(1), slices.Search(s, 3, funcs.Order[int]))
+	assert.Equal(t, slices.Found(4), slices.Search(s, 7, funcs.Order[int]))
+	assert.Equal(t, slices.InsertionPoint(0), slices.Search(s, 0, funcs.Order[int]))
+	assert.Equal(t, slices.InsertionPoint(3), slices.Search(s, 4, funcs.Order[int]))
+	assert.Equal(t, slices.InsertionPoint(5), slices.Search(s, 8, funcs.Order[int]))
+	assert.Equal(t, slices.InsertionPoint(0), slices.Search(slices.Empty[int](), 1, funcs.Order[int]))
+
+	r := slices.Search(s, 5, funcs.Order[int])
+	assert.True(t, r.IsFound())
+	assert.Equal(t, 3, r.Index())
+	assert.Equal(t, "Found(3)", r.String())
+	assert.Equal(t, "InsertionPoint(2)", slices.InsertionPoint(2).String())
+
+	people := slices.Map(s, func(v int) person { return person{age: v} })
+	byAge := func(p person, age int) int { return funcs.Order(p.age, age) }
+	assert.Equal(t, slices.Found(3), slices.Search(people, 5, byAge))
+}
+
+func TestIsSorted(t *testing.T) {
+	assert.True(t, slices.IsSorted(slices.From(1, 2, 2, 3), funcs.Order[int]))
+	assert.False(t, slices.IsSorted(slices.From(1, 3, 2), funcs.Order[int]))
+	assert.True(t, slices.IsSorted(slices.From(3, 2, 1), funcs.Reverse(funcs.Order[int])))
+	assert.True(t, slices.IsSorted(slices.Empty[int](), funcs.Order[int]))
+}
+
+func TestInsertSorted(t *testing.T) {
+	s := slices.From(1, 3, 5)
+
+	assert.Equal(t, slices.From(0, 1, 3, 5), slices.InsertSorted(s, 0, funcs.Order[int]))
+	assert.Equal(t, slices.From(1, 3, 4, 5), slices.InsertSorted(s, 4, funcs.Order[int]))
+	assert.Equal(t, slices.From(1, 3, 5, 6), slices.InsertSorted(s, 6, funcs.Order[int]))
+	assert.Equal(t, slices.From(1, 3, 5), s)
+
+	people := slices.From(person{age: 1}, person{age: 2})
+	x := person{age: 1}
+	ret := slices.InsertSorted(people, x, funcs.By(orderize))
+	assert.Equal(t, slices.From(person{age: 1}, x, person{age: 2}), ret)
+}
+
+func TestMergeSorted(t *testing.T) {
+	prop := func(a, b []int) bool {
+		x := slices.S[int](a).Clone().Sort(funcs.Order[int])
+		y := slices.S[int](b).Clone().Sort(funcs.Order[int])
+		expected := append(x.Clone(), y...).Sort(funcs.Order[int])
+		return slices.Equal(expected, slices.MergeSorted(x, y, funcs.Order[int]))
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+}
+
+func TestSorted(t *testing.T) {
+	s := slices.NewSorted(funcs.Order[int], 5, 1, 3)
+	assert.Equal(t, slices.From(1, 3, 5), s.Slice())
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, "Sorted[1 3 5]", s.String())
+
+	s.Add(4, 0, 3)
+	assert.Equal(t, slices.From(0, 1, 3, 3, 4, 5), s.Slice())
+	assert.Equal(t, gs.Some(0), s.Head())
+	assert.Equal(t, gs.Some(5), s.Last())
+	assert.Equal(t, 4, s.Get(4))
+	assert.True(t, s.Contain(4))
+	assert.Equal(t, slices.Found(2), s.Search(3))
+
+	s.Remove(3, 0, 9)
+	assert.Equal(t, slices.From(1, 3, 4, 5), s.Slice())
+	assert.False(t, s.Contain(0))
+	assert.Equal(t, slices.InsertionPoint(0), s.Search(0))
+
+	s.Slice()[0] = 100
+	assert.Equal(t, slices.From(1, 3, 4, 5), s.Slice())
+
+	prop := func(a []int) bool {
+		x := slices.NewSorted(funcs.Order[int]).Add(a...)
+		return slices.IsSorted(x.Slice(), funcs.Order[int]) && x.Len() == len(a)
+	}
+	assert.Nil(t, quick.Check(prop, nil))
+
+	empty := slices.NewSorted(funcs.Order[int])
+	assert.True(t, empty.IsEmpty())
+	assert.False(t, empty.Head().IsDefined())
+}
+
+func TestSum(t *testing.T) {
+	assert.Equal(t, 15, slices.Sum(slices.Range(1, 6, 1)))
+	assert.Equal(t, 0, slices.Sum(slices.Empty[int]()))
+	assert.Equal(t, 6, slices.SumBy(slices.From("1", "2", "3"), func(v string) int { return len(v) * 2 }))
+
+	// naive summation of 0.1 drifts away from 1e6
+	s := slices.Fill(10000000, 0.1)
+	assert.Equal(t, 1000000.0, slices.Sum(s))
+
+	s = slices.From(1.0, 1e100, 1.0, -1e100)
+	assert.Equal(t, 2.0, slices.Sum(s))
+	assert.Equal(t, float32(3.0), slices.Sum(slices.From[float32](1, 1, 1)))
+}
+
+func TestProduct(t *testing.T) {
+	assert.Equal(t, 120, slices.Product(slices.Range(1, 6, 1)))
+	assert.Equal(t, 1, slices.Product(slices.Empty[int]()))
+}
+
+func TestMean(t *testing.T) {
+	assert.Equal(t, gs.Some(2.5), slices.Mean(slices.From(1, 2, 3, 4)))
+	assert.True(t, slices.Mean(slices.Empty[int]()).IsEmpty())
+	assert.Equal(t, gs.Some(2.0), slices.MeanBy(slices.From("a", "bb", "ccc"), func(v string) int { return len(v) }))
+	assert.Equal(t, gs.Some(0.1), slices.Mean(slices.Fill(1000000, 0.1)))
+}
+
+func TestVariance(t *testing.T) {
+	s := slices.From(2, 4, 4, 4, 5, 5, 7, 9)
+	assert.Equal(t, gs.Some(4.0), slices.Variance(s))
+	assert.Equal(t, gs.Some(2.0), slices.StdDev(s))
+	assert.True(t, slices.Variance(slices.Empty[int]()).IsEmpty())
+	assert.True(t, slices.StdDev(slices.Empty[int]()).IsEmpty())
+	assert.Equal(t, gs.Some(0.0), slices.Variance(slices.One(1e9)))
+}
+
+func TestPercentile(t *testing.T) {
+	s := slices.From(15, 20, 35, 40, 50)
+
+	assert.Equal(t, gs.Some(15.0), slices.Percentile(s, 0))
+	assert.Equal(t, gs.Some(50.0), slices.Percentile(s, 100))
+	assert.Equal(t, gs.Some(35.0), slices.Percentile(s, 50))
+	assert.Equal(t, gs.Some(29.0), slices.Percentile(s, 40))
+	assert.True(t, slices.Percentile(s, 101).IsEmpty())
+	assert.True(t, slices.Percentile(s, -1).IsEmpty())
+	assert.True(t, slices.Percentile(s, math.NaN()).IsEmpty())
+	assert.True(t, slices.Percentile(slices.Empty[int](), 50).IsEmpty())
+
+	assert.Equal(t, gs.Some(35.0), slices.Median(slices.From(50, 15, 40, 35, 20)))
+	assert.Equal(t, gs.Some(2.5), slices.Median(slices.From(4, 1, 3, 2)))
+	assert.Equal(t, gs.Some(7.0), slices.Median(slices.One(7)))
+}
+
+func TestHistogram(t *testing.T) {
+	s := slices.From(-1, 0, 1, 5, 9, 10, 11, 20, 30)
+	assert.Equal(t, slices.From(1, 4, 2, 2), slices.Histogram(s, slices.From(0, 10, 20)))
+	assert.Equal(t, slices.From(0, 0), slices.Histogram(slices.Empty[int](), slices.One(1)))
+	assert.Equal(t, slices.One(len(s)), slices.Histogram(s, slices.Empty[int]()))
+}
+
+func TestMinMax(t *testing.T) {
+	assert.Equal(t, gs.Some(gs.T2(-2, 9)), slices.MinMax(slices.From(3, 9, -2, 4)))
+	assert.Equal(t, gs.Some(gs.T2(1.5, 1.5)), slices.MinMax(slices.One(1.5)))
+	assert.True(t, slices.MinMax(slices.Empty[int]()).IsEmpty())
+}
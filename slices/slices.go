@@ -150,6 +150,110 @@ func Equal[T comparable](s1 S[T], s2 S[T]) bool {
 	return EqualFunc(s1, s2, funcs.Same[T])
 }
 
+// DistinctBy returns a new slice without elements having the same key from given function key. The first occurrence of each key is kept.
+func DistinctBy[T any, K comparable](s S[T], key funcs.Func[T, K]) S[T] {
+	seen := make(map[K]gs.Nothing, len(s))
+	ret := Empty[T]()
+	for i := range s {
+		k := key(s[i])
+		if _, ok := seen[k]; !ok {
+			seen[k] = gs.N()
+			ret = append(ret, s[i])
+		}
+	}
+	return ret
+}
+
+// Distinct returns a new slice without duplicate elements. The first occurrence of each element is kept.
+func Distinct[T comparable](s S[T]) S[T] {
+	return DistinctBy(s, funcs.Self[T])
+}
+
+// DistinctFunc returns a new slice without elements equal to a previous one according to given function eq.
+// It takes O(n^2) time, and Distinct or DistinctBy should be used if possible.
+func DistinctFunc[T any](s S[T], eq funcs.Equal[T, T]) S[T] {
+	ret := Empty[T]()
+	for i := range s {
+		if !ContainFunc(ret, s[i], eq) {
+			ret = append(ret, s[i])
+		}
+	}
+	return ret
+}
+
+// counts returns occurrences of each element in s.
+func counts[T comparable](s S[T]) map[T]int {
+	ret := make(map[T]int, len(s))
+	for i := range s {
+		ret[s[i]]++
+	}
+	return ret
+}
+
+// Intersect returns the multiset intersection of s1 and s2. If an element appears n times in s2, the first n occurrences of it in s1 are kept.
+func Intersect[T comparable](s1, s2 S[T]) S[T] {
+	n := counts(s2)
+	return s1.Filter(func(x T) bool {
+		n[x]--
+		return n[x] >= 0
+	})
+}
+
+// Diff returns the multiset difference of s1 and s2. If an element appears n times in s2, the first n occurrences of it in s1 are removed.
+func Diff[T comparable](s1, s2 S[T]) S[T] {
+	n := counts(s2)
+	return s1.Filter(func(x T) bool {
+		n[x]--
+		return n[x] < 0
+	})
+}
+
+// Union returns the multiset union of s1 and s2. If an element appears n times in s1 and m times in s2, it appears max(n, m) times in the result.
+// Elements of s1 come first, followed by the last m-n occurrences of the element in s2 if m > n.
+func Union[T comparable](s1, s2 S[T]) S[T] {
+	ret := make(S[T], 0, len(s1)+len(s2))
+	return append(append(ret, s1...), Diff(s2, s1)...)
+}
+
+// matches marks elements of s1 matched one-to-one with elements of s2 according to given function eq.
+func matches[T, U any](s1 S[T], s2 S[U], eq funcs.Equal[T, U]) []bool {
+	used := make([]bool, len(s2))
+	ret := make([]bool, len(s1))
+	for i := range s1 {
+		for j := range s2 {
+			if !used[j] && eq(s1[i], s2[j]) {
+				used[j], ret[i] = true, true
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// IntersectFunc is Intersect with given equality function eq. It takes O(n*m) time.
+func IntersectFunc[T, U any](s1 S[T], s2 S[U], eq funcs.Equal[T, U]) S[T] {
+	m := matches(s1, s2, eq)
+	ret := Empty[T]()
+	for i := range s1 {
+		if m[i] {
+			ret = append(ret, s1[i])
+		}
+	}
+	return ret
+}
+
+// DiffFunc is Diff with given equality function eq. It takes O(n*m) time.
+func DiffFunc[T, U any](s1 S[T], s2 S[U], eq funcs.Equal[T, U]) S[T] {
+	m := matches(s1, s2, eq)
+	ret := Empty[T]()
+	for i := range s1 {
+		if !m[i] {
+			ret = append(ret, s1[i])
+		}
+	}
+	return ret
+}
+
 // Collect returns a new slice containing results applying given partial function p on which it is defined.
 func Collect[T, U any](s S[T], p funcs.Partial[T, U]) S[U] {
 	return Fold(
@@ -287,22 +391,112 @@ func GroupMapReduce[T any, K comparable, V any](s S[T], key funcs.Func[T, K], va
 
 // MaxBy returns Some with the maximum value in s according to the ordered results transformed from given ordering function op.
 func MaxBy[T any, R constraints.Ordered](s S[T], op funcs.Orderize[T, R]) gs.Option[T] {
-	return s.Max(func(a, b T) int { return funcs.Order(op(a), op(b)) })
+	return s.Max(funcs.By(op))
+}
+
+// MaxByFunc returns Some with the maximum value in s according to results of given function key compared with given cmp.
+func MaxByFunc[T, K any](s S[T], key funcs.Func[T, K], cmp funcs.Ordering[K, K]) gs.Option[T] {
+	return s.Max(on(key, cmp))
 }
 
 // MinBy returns Some with minimum value in s according to the ordered results transformed from given ordering function op.
 func MinBy[T any, R constraints.Ordered](s S[T], op funcs.Orderize[T, R]) gs.Option[T] {
-	return s.Min(func(a, b T) int { return funcs.Order(op(a), op(b)) })
+	return s.Min(funcs.By(op))
+}
+
+// MinByFunc returns Some with the minimum value in s according to results of given function key compared with given cmp.
+func MinByFunc[T, K any](s S[T], key funcs.Func[T, K], cmp funcs.Ordering[K, K]) gs.Option[T] {
+	return s.Min(on(key, cmp))
 }
 
 // SortBy sorts a slice according to the ordered results transformed from given ordering function op.
 func SortBy[T any, R constraints.Ordered](s S[T], op funcs.Orderize[T, R]) S[T] {
-	return s.Sort(func(a, b T) int {
-		return funcs.Order(op(a), op(b))
-	})
+	return s.Sort(funcs.By(op))
+}
+
+// SortByFunc sorts a slice according to results of given function key compared with given cmp.
+func SortByFunc[T, K any](s S[T], key funcs.Func[T, K], cmp funcs.Ordering[K, K]) S[T] {
+	return s.Sort(on(key, cmp))
+}
+
+// on returns an Ordering comparing results of given function key with given cmp.
+func on[T, K any](key funcs.Func[T, K], cmp funcs.Ordering[K, K]) funcs.Ordering[T, T] {
+	return func(a, b T) int {
+		return cmp(key(a), key(b))
+	}
+}
+
+// Lexicographic returns an Ordering of slices comparing elements with given cmp in turn. A prefix is less than the longer one.
+func Lexicographic[T any](cmp funcs.Ordering[T, T]) funcs.Ordering[S[T], S[T]] {
+	return func(a, b S[T]) int {
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if ret := cmp(a[i], b[i]); ret != 0 {
+				return ret
+			}
+		}
+		return funcs.Order(len(a), len(b))
+	}
 }
 
 // IsEmpty returns true if the given slice is empty.
 func IsEmpty[T any](s S[T]) bool {
 	return len(s) <= 0
 }
+
+// ChunkBy partitions s into runs of consecutive elements with the same key from given function key.
+func ChunkBy[T any, K comparable](s S[T], key funcs.Func[T, K]) S[S[T]] {
+	return s.ChunkWhile(func(a, b T) bool {
+		return key(a) == key(b)
+	})
+}
+
+// ZipWith returns a new slice with results of applying given function op to pairs of elements from a and b at the same index.
+// The result is truncated to the length of the shorter one.
+func ZipWith[A, B, R any](a S[A], b S[B], op func(A, B) R) S[R] {
+	size := funcs.Min(len(a), len(b))
+	ret := make(S[R], size)
+	for i := 0; i < size; i++ {
+		ret[i] = op(a[i], b[i])
+	}
+	return ret
+}
+
+// Zip returns a new slice pairing elements of a and b at the same index.
+// The result is truncated to the length of the shorter one.
+func Zip[A, B any](a S[A], b S[B]) S[gs.Tuple2[A, B]] {
+	return ZipWith(a, b, gs.T2[A, B])
+}
+
+// ZipAll returns a new slice pairing elements of a and b at the same index.
+// The shorter one is padded with za or zb to the length of the longer one.
+func ZipAll[A, B any](a S[A], b S[B], za A, zb B) S[gs.Tuple2[A, B]] {
+	if len(a) < len(b) {
+		a = append(a.Clone(), Fill(len(b)-len(a), za)...)
+	} else {
+		b = append(b.Clone(), Fill(len(a)-len(b), zb)...)
+	}
+	return Zip(a, b)
+}
+
+// ZipWithIndex returns a new slice pairing each element of s with its index.
+func ZipWithIndex[T any](s S[T]) S[gs.Tuple2[T, int]] {
+	return Zip(s, Tabulate(len(s), funcs.Self[int]))
+}
+
+// Unzip splits a slice of pairs into two slices of first and second elements.
+func Unzip[A, B any](s S[gs.Tuple2[A, B]]) (S[A], S[B]) {
+	a, b := make(S[A], len(s)), make(S[B], len(s))
+	for i := range s {
+		a[i], b[i] = s[i].V1, s[i].V2
+	}
+	return a, b
+}
+
+// Unzip3 splits a slice of triples into three slices of first, second and third elements.
+func Unzip3[A, B, C any](s S[gs.Tuple3[A, B, C]]) (S[A], S[B], S[C]) {
+	a, b, c := make(S[A], len(s)), make(S[B], len(s)), make(S[C], len(s))
+	for i := range s {
+		a[i], b[i], c[i] = s[i].V1, s[i].V2, s[i].V3
+	}
+	return a, b, c
+}
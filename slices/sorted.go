@@ -0,0 +1,203 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package slices
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+)
+
+// SearchResult is result of Search like SearchResult in Scala. It is either Found or InsertionPoint.
+type SearchResult interface {
+	fmt.Stringer
+
+	// IsFound returns true if this is a Found.
+	IsFound() bool
+
+	// Index returns index of the found element if this is a Found, or returns the insertion point.
+	Index() int
+}
+
+type found int
+
+func (f found) IsFound() bool {
+	return true
+}
+
+func (f found) Index() int {
+	return int(f)
+}
+
+func (f found) String() string {
+	return fmt.Sprintf(`Found(%d)`, int(f))
+}
+
+type insertionPoint int
+
+func (p insertionPoint) IsFound() bool {
+	return false
+}
+
+func (p insertionPoint) Index() int {
+	return int(p)
+}
+
+func (p insertionPoint) String() string {
+	return fmt.Sprintf(`InsertionPoint(%d)`, int(p))
+}
+
+// Found returns a SearchResult with index of found element.
+func Found(index int) SearchResult {
+	return found(index)
+}
+
+// InsertionPoint returns a SearchResult with index where the element would be inserted.
+func InsertionPoint(index int) SearchResult {
+	return insertionPoint(index)
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// Search searches given x in s sorted by given cmp with binary search.
+// It returns Found with index of the first element equal to x, or returns InsertionPoint where x would be inserted to keep s sorted.
+func Search[T, U any](s S[T], x U, cmp funcs.Ordering[T, U]) SearchResult {
+	i := sort.Search(len(s), func(i int) bool { return cmp(s[i], x) >= 0 })
+	if i < len(s) && cmp(s[i], x) == 0 {
+		return Found(i)
+	}
+	return InsertionPoint(i)
+}
+
+// IsSorted returns true if s is sorted by given cmp.
+func IsSorted[T any](s S[T], cmp funcs.Ordering[T, T]) bool {
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i-1], s[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// upper returns index of the first element in s larger than x.
+func upper[T any](s S[T], x T, cmp funcs.Ordering[T, T]) int {
+	return sort.Search(len(s), func(i int) bool { return cmp(s[i], x) > 0 })
+}
+
+// InsertSorted returns a new slice with given x inserted into s sorted by given cmp.
+// x is inserted after elements equal to it.
+func InsertSorted[T any](s S[T], x T, cmp funcs.Ordering[T, T]) S[T] {
+	i := upper(s, x, cmp)
+	ret := make(S[T], len(s)+1)
+	copy(ret, s[:i])
+	ret[i] = x
+	copy(ret[i+1:], s[i:])
+	return ret
+}
+
+// MergeSorted merges s1 and s2 sorted by given cmp into a new sorted slice in linear time.
+// Elements of s1 are placed before equal elements of s2.
+func MergeSorted[T any](s1, s2 S[T], cmp funcs.Ordering[T, T]) S[T] {
+	ret := make(S[T], 0, len(s1)+len(s2))
+	i, j := 0, 0
+	for i < len(s1) && j < len(s2) {
+		if cmp(s2[j], s1[i]) < 0 {
+			ret = append(ret, s2[j])
+			j++
+		} else {
+			ret = append(ret, s1[i])
+			i++
+		}
+	}
+	ret = append(ret, s1[i:]...)
+	return append(ret, s2[j:]...)
+}
+
+// -----------------------------------------------------------------------------
+
+// Sorted is a slice kept sorted by an ordering function.
+type Sorted[T any] struct {
+	_   struct{}
+	s   S[T]
+	cmp funcs.Ordering[T, T]
+}
+
+// NewSorted returns a Sorted with given ordering function cmp and elements.
+func NewSorted[T any](cmp funcs.Ordering[T, T], a ...T) *Sorted[T] {
+	return &Sorted[T]{
+		s:   From(a...).Clone().Sort(cmp),
+		cmp: cmp,
+	}
+}
+
+// Len returns numbers of elements in this.
+func (s *Sorted[T]) Len() int {
+	return len(s.s)
+}
+
+// IsEmpty returns true if this has no element.
+func (s *Sorted[T]) IsEmpty() bool {
+	return len(s.s) <= 0
+}
+
+// Get returns the element at given index i.
+func (s *Sorted[T]) Get(i int) T {
+	return s.s[i]
+}
+
+// Head returns Some with the minimum element, or returns None if this is empty.
+func (s *Sorted[T]) Head() gs.Option[T] {
+	return s.s.Head()
+}
+
+// Last returns Some with the maximum element, or returns None if this is empty.
+func (s *Sorted[T]) Last() gs.Option[T] {
+	return s.s.Last()
+}
+
+// Add inserts given elements into this. Each element is inserted after elements equal to it.
+func (s *Sorted[T]) Add(a ...T) *Sorted[T] {
+	for i := range a {
+		n := upper(s.s, a[i], s.cmp)
+		s.s = append(s.s, a[i])
+		copy(s.s[n+1:], s.s[n:])
+		s.s[n] = a[i]
+	}
+	return s
+}
+
+// Remove removes first element equal to each of given elements from this.
+func (s *Sorted[T]) Remove(a ...T) *Sorted[T] {
+	for i := range a {
+		if r := s.Search(a[i]); r.IsFound() {
+			s.s = append(s.s[:r.Index()], s.s[r.Index()+1:]...)
+		}
+	}
+	return s
+}
+
+// Search searches given x in this. See also Search function.
+func (s *Sorted[T]) Search(x T) SearchResult {
+	return Search(s.s, x, s.cmp)
+}
+
+// Contain returns true if this has an element equal to given x.
+func (s *Sorted[T]) Contain(x T) bool {
+	return s.Search(x).IsFound()
+}
+
+// Slice returns a sorted slice containing all elements of this.
+func (s *Sorted[T]) Slice() S[T] {
+	return s.s.Clone()
+}
+
+func (s *Sorted[T]) String() string {
+	return fmt.Sprintf(`Sorted%v`, []T(s.s))
+}
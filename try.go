@@ -6,6 +6,7 @@
 package gs
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/dairaga/gs/funcs"
@@ -42,10 +43,10 @@ type Try[T any] interface {
 	// Foreach only applies given function op to value from Success.
 	Foreach(op func(T))
 
-	// Filter returns this if this is a Failure or value from Success satisfies given function p, otherwise returns Failure with ErrUnsatisfied.
+	// Filter returns this if this is a Failure or value from Success satisfies given function p, otherwise returns Failure with UnsatisfiedError wrapping ErrUnsatisfied and the value.
 	Filter(p funcs.Predict[T]) Try[T]
 
-	// FilterNot returns this if this is a Failure or value from Succes does not satisfy given function p, otherwise returns Failure with ErrUnsatisfied.
+	// FilterNot returns this if this is a Failure or value from Succes does not satisfy given function p, otherwise returns Failure with UnsatisfiedError wrapping ErrUnsatisfied and the value.
 	FilterNot(funcs.Predict[T]) Try[T]
 
 	// GetOrElse returns value from Success, or returns given z.
@@ -80,6 +81,18 @@ func (t *try[T]) String() string {
 	return fmt.Sprintf(`Failure(%s)`, t.left.Error())
 }
 
+// MarshalJSON encodes a Success to {"success": value}, or encodes a Failure to {"failure": message of error}.
+// A Failure with nil error is encoded to {"failure": null}.
+func (t *try[T]) MarshalJSON() ([]byte, error) {
+	if t.ok {
+		return json.Marshal(map[string]T{"success": t.right})
+	}
+	if t.left == nil {
+		return []byte(`{"failure":null}`), nil
+	}
+	return json.Marshal(map[string]string{"failure": t.left.Error()})
+}
+
 func (t *try[T]) Fetch() (T, error) {
 	return t.right, t.left
 }
@@ -108,7 +121,7 @@ func (t *try[T]) Filter(p funcs.Predict[T]) Try[T] {
 		return t
 	}
 
-	return failure[T](ErrUnsatisfied)
+	return failure[T](&UnsatisfiedError[T]{Value: t.right})
 }
 
 func (t *try[T]) FilterNot(p funcs.Predict[T]) Try[T] {
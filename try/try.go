@@ -6,6 +6,9 @@
 package try
 
 import (
+	"errors"
+	"runtime/debug"
+
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
 )
@@ -20,6 +23,50 @@ func FromWithBool[T any](v T, ok bool) gs.Try[T] {
 	return From(v, funcs.Cond(ok, nil, gs.ErrUnsatisfied))
 }
 
+// capture recovers from panic, and sets given ret to a Failure with PanicError if the function did not return.
+// Checking returned catches panic(nil) also, because recover returns nil for it before go 1.21.
+func capture[T any](ret *gs.Try[T], returned *bool) {
+	if r := recover(); r != nil || !*returned {
+		*ret = gs.Failure[T](&gs.PanicError{
+			Value: r,
+			Stack: debug.Stack(),
+		})
+	}
+}
+
+// Catch returns a Success with result from given function op, or returns a Failure with PanicError if op panics.
+func Catch[T any](op func() T) (ret gs.Try[T]) {
+	returned := false
+	defer capture(&ret, &returned)
+	v := op()
+	returned = true
+	return gs.Success(v)
+}
+
+// CatchErr returns a Try built from result of given function op, or returns a Failure with PanicError if op panics.
+func CatchErr[T any](op func() (T, error)) (ret gs.Try[T]) {
+	returned := false
+	defer capture(&ret, &returned)
+	v, err := op()
+	returned = true
+	return From(v, err)
+}
+
+// Must returns successful value from given t.
+// It panics with the original value if t is a Failure with PanicError, or panics with error from t.
+func Must[T any](t gs.Try[T]) T {
+	v, err := t.Fetch()
+	if err == nil {
+		return v
+	}
+
+	var perr *gs.PanicError
+	if errors.As(err, &perr) {
+		panic(perr.Value)
+	}
+	panic(err)
+}
+
 // -----------------------------------------------------------------------------
 
 // TODO: refactor following functions to methods when go 1.19 releases.
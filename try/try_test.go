@@ -6,12 +6,15 @@
 package try_test
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
 	"testing"
 
 	"github.com/dairaga/gs"
 	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/option"
+	"github.com/dairaga/gs/slices"
 	"github.com/dairaga/gs/try"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,7 +27,7 @@ func assertTry[T any](t *testing.T, a, b gs.Try[T]) {
 	bval, berr := b.Fetch()
 
 	assert.Equal(t, aval, bval)
-	assert.True(t, errors.Is(aerr, berr))
+	assert.True(t, errors.Is(berr, aerr))
 }
 
 func TestFrom(t *testing.T) {
@@ -150,3 +153,85 @@ func TestTransform(t *testing.T) {
 	)
 
 }
+
+func TestTJSON(t *testing.T) {
+	type st struct {
+		A try.T[int]                      `json:"a"`
+		B try.T[slices.S[gs.Option[int]]] `json:"b"`
+	}
+
+	src := st{
+		A: try.Of(gs.Failure[int](gs.ErrEmpty)),
+		B: try.Of(gs.Success(slices.From(gs.Some(1), gs.None[int]()))),
+	}
+
+	data, err := json.Marshal(src)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"failure":"empty"},"b":{"success":[1,null]}}`, string(data))
+
+	var dst struct {
+		A try.T[int]                     `json:"a"`
+		B try.T[slices.S[option.O[int]]] `json:"b"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &dst))
+	assert.True(t, dst.A.IsFailure())
+	assert.Equal(t, gs.ErrEmpty.Error(), dst.A.Failed().Error())
+	assert.Equal(t, 1, dst.B.Get()[0].Get())
+	assert.True(t, dst.B.Get()[1].IsEmpty())
+
+	var zero try.T[int]
+	assertTry(t, gs.Failure[int](gs.ErrEmpty), zero.Try())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"a":{"failure":1}}`), &dst))
+
+	data, err = json.Marshal(st{A: try.Of(gs.Failure[int](nil))})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"failure":null},"b":{"failure":"empty"}}`, string(data))
+	assert.Nil(t, json.Unmarshal(data, &dst))
+	assert.True(t, dst.A.IsFailure())
+	assert.Nil(t, dst.A.Failed())
+}
+
+func TestCatch(t *testing.T) {
+	assertTry(t, gs.Success(1), try.Catch(funcs.Id(1)))
+
+	result := try.Catch(func() int { panic("boom") })
+	assert.True(t, result.IsFailure())
+
+	var perr *gs.PanicError
+	assert.True(t, errors.As(result.Failed(), &perr))
+	assert.Equal(t, "boom", perr.Value)
+	assert.Contains(t, string(perr.Stack), "TestCatch")
+
+	result = try.Catch(func() int { panic(gs.ErrEmpty) })
+	assert.True(t, errors.Is(result.Failed(), gs.ErrEmpty))
+
+	result = try.Catch(func() int { panic(nil) })
+	assert.NotNil(t, result)
+	assert.True(t, result.IsFailure())
+	assert.True(t, errors.As(result.Failed(), &perr))
+
+	result = try.CatchErr(func() (int, error) { panic(nil) })
+	assert.NotNil(t, result)
+	assert.True(t, result.IsFailure())
+	assert.True(t, errors.As(result.Failed(), &perr))
+}
+
+func TestCatchErr(t *testing.T) {
+	assertTry(t, gs.Success(1), try.CatchErr(func() (int, error) { return 1, nil }))
+	assertTry(t, gs.Failure[int](gs.ErrEmpty), try.CatchErr(func() (int, error) { return 0, gs.ErrEmpty }))
+
+	result := try.CatchErr(func() (int, error) { panic("boom") })
+	var perr *gs.PanicError
+	assert.True(t, errors.As(result.Failed(), &perr))
+	assert.Equal(t, "boom", perr.Value)
+}
+
+func TestMust(t *testing.T) {
+	assert.Equal(t, 1, try.Must(gs.Success(1)))
+	assert.PanicsWithValue(t, "boom", func() {
+		try.Must(try.Catch(func() int { panic("boom") }))
+	})
+	assert.PanicsWithError(t, gs.ErrEmpty.Error(), func() {
+		try.Must(gs.Failure[int](gs.ErrEmpty))
+	})
+}
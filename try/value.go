@@ -0,0 +1,146 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package try
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+)
+
+// T is a value type of Try, and can be a field of struct. Zero value of T is a Failure with ErrEmpty.
+// T is encoded to JSON object {"success": value} if it is a Success, or {"failure": message of error} if it is a Failure.
+// Error decoded from JSON only keeps the message, and a Failure with nil error is encoded to {"failure": null}.
+type T[V any] struct {
+	_ struct{}
+	t gs.Try[V]
+}
+
+var _ gs.Try[int] = T[int]{}
+
+// Of returns a T from given Try t.
+func Of[V any](t gs.Try[V]) T[V] {
+	return T[V]{t: t}
+}
+
+// Try returns Try of this.
+func (t T[V]) Try() gs.Try[V] {
+	if t.t == nil {
+		return gs.Failure[V](gs.ErrEmpty)
+	}
+	return t.t
+}
+
+func (t T[V]) String() string {
+	return t.Try().String()
+}
+
+func (t T[V]) Fetch() (V, error) {
+	return t.Try().Fetch()
+}
+
+func (t T[V]) Get() V {
+	return t.Try().Get()
+}
+
+func (t T[V]) IsSuccess() bool {
+	return t.Try().IsSuccess()
+}
+
+func (t T[V]) Success() V {
+	return t.Try().Success()
+}
+
+func (t T[V]) IsFailure() bool {
+	return t.Try().IsFailure()
+}
+
+func (t T[V]) Failed() error {
+	return t.Try().Failed()
+}
+
+func (t T[V]) Exists(p funcs.Predict[V]) bool {
+	return t.Try().Exists(p)
+}
+
+func (t T[V]) Forall(p funcs.Predict[V]) bool {
+	return t.Try().Forall(p)
+}
+
+func (t T[V]) Foreach(op func(V)) {
+	t.Try().Foreach(op)
+}
+
+func (t T[V]) Filter(p funcs.Predict[V]) gs.Try[V] {
+	return t.Try().Filter(p)
+}
+
+func (t T[V]) FilterNot(p funcs.Predict[V]) gs.Try[V] {
+	return t.Try().FilterNot(p)
+}
+
+func (t T[V]) GetOrElse(z V) V {
+	return t.Try().GetOrElse(z)
+}
+
+func (t T[V]) OrElse(z gs.Try[V]) gs.Try[V] {
+	return t.Try().OrElse(z)
+}
+
+func (t T[V]) Recover(r funcs.Func[error, V]) gs.Try[V] {
+	return t.Try().Recover(r)
+}
+
+func (t T[V]) RecoverWith(r funcs.Func[error, gs.Try[V]]) gs.Try[V] {
+	return t.Try().RecoverWith(r)
+}
+
+func (t T[V]) Either() gs.Either[error, V] {
+	return t.Try().Either()
+}
+
+func (t T[V]) Option() gs.Option[V] {
+	return t.Try().Option()
+}
+
+func (t T[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Try())
+}
+
+func (t *T[V]) UnmarshalJSON(data []byte) error {
+	var tagged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return err
+	}
+
+	success, isSuccess := tagged["success"]
+	failure, isFailure := tagged["failure"]
+
+	switch {
+	case len(tagged) == 1 && isSuccess:
+		var v V
+		if err := json.Unmarshal(success, &v); err != nil {
+			return err
+		}
+		t.t = gs.Success(v)
+	case len(tagged) == 1 && isFailure:
+		var msg *string
+		if err := json.Unmarshal(failure, &msg); err != nil {
+			return err
+		}
+		if msg == nil {
+			t.t = gs.Failure[V](nil)
+		} else {
+			t.t = gs.Failure[V](errors.New(*msg))
+		}
+	default:
+		return fmt.Errorf(`try: JSON must contain exactly one of "success" and "failure": %s`, data)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@
 package gs_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -21,7 +22,7 @@ func assertTry[T any](t *testing.T, a, b gs.Try[T]) {
 	bval, berr := b.Fetch()
 
 	assert.Equal(t, aval, bval)
-	assert.True(t, errors.Is(aerr, berr))
+	assert.True(t, errors.Is(berr, aerr))
 }
 
 func TestSuccess(t *testing.T) {
@@ -153,3 +154,27 @@ func TestTryRecoverWith(t *testing.T) {
 	assertTry(t, gs.Success(gs.ErrLeft.Error()), gs.Failure[string](gs.ErrLeft).RecoverWith(r))
 	assertTry(t, gs.Failure[string](gs.ErrEmpty), gs.Failure[string](gs.ErrEmpty).RecoverWith(r))
 }
+
+func TestTryMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(gs.Success(1))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"success":1}`, string(data))
+
+	data, err = json.Marshal(gs.Failure[int](gs.ErrEmpty))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"failure":"empty"}`, string(data))
+
+	data, err = json.Marshal(gs.Failure[int](nil))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"failure":null}`, string(data))
+}
+
+func TestUnsatisfiedError(t *testing.T) {
+	try := gs.Success(-1).Filter(func(v int) bool { return v > 0 })
+	assert.True(t, errors.Is(try.Failed(), gs.ErrUnsatisfied))
+
+	var err *gs.UnsatisfiedError[int]
+	assert.True(t, errors.As(try.Failed(), &err))
+	assert.Equal(t, -1, err.Value)
+	assert.Equal(t, "unsatisfied: -1", err.Error())
+}
@@ -0,0 +1,12 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+/*
+Package vector implements a persistent immutable vector like Vector in Scala.
+
+A vector is a 32-way trie with structural sharing. Operations returning a vector never modify the receiver,
+so vectors can be shared between goroutines safely.
+*/
+package vector
@@ -0,0 +1,95 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+// node is a node of trie. Leaves hold values and branches hold children.
+type node[T any] struct {
+	_        struct{}
+	children []*node[T]
+	values   []T
+}
+
+// clone returns a copy of n, or returns a new node if n is nil.
+func (n *node[T]) clone(leaf bool) *node[T] {
+	ret := &node[T]{}
+	if leaf {
+		ret.values = make([]T, width)
+		if n != nil {
+			copy(ret.values, n.values)
+		}
+	} else {
+		ret.children = make([]*node[T], width)
+		if n != nil {
+			copy(ret.children, n.children)
+		}
+	}
+	return ret
+}
+
+// set returns a copy of n with value v at index i in a trie of given shift.
+func (n *node[T]) set(shift uint, i int, v T) *node[T] {
+	ret := n.clone(shift == 0)
+	if shift == 0 {
+		ret.values[i&mask] = v
+	} else {
+		idx := (i >> shift) & mask
+		ret.children[idx] = ret.children[idx].set(shift-bits, i, v)
+	}
+	return ret
+}
+
+// push returns a copy of n with given values from index i in a trie of given shift. All values must be in the same leaf.
+func (n *node[T]) push(shift uint, i int, values []T) *node[T] {
+	ret := n.clone(shift == 0)
+	if shift == 0 {
+		copy(ret.values[i&mask:], values)
+	} else {
+		idx := (i >> shift) & mask
+		ret.children[idx] = ret.children[idx].push(shift-bits, i, values)
+	}
+	return ret
+}
+
+// leaf returns the leaf containing index i in a trie of given shift.
+func (n *node[T]) leaf(shift uint, i int) *node[T] {
+	for ; shift > 0; shift -= bits {
+		n = n.children[(i>>shift)&mask]
+	}
+	return n
+}
+
+// build builds a trie containing given values from index 0, and returns its root and shift.
+func build[T any](values []T) (*node[T], uint) {
+	if len(values) <= 0 {
+		return nil, 0
+	}
+
+	nodes := make([]*node[T], 0, (len(values)+mask)/width)
+	for i := 0; i < len(values); i += width {
+		n := (*node[T])(nil).clone(true)
+		copy(n.values, values[i:])
+		nodes = append(nodes, n)
+	}
+
+	shift := uint(0)
+	for len(nodes) > 1 {
+		parents := make([]*node[T], 0, (len(nodes)+mask)/width)
+		for i := 0; i < len(nodes); i += width {
+			n := (*node[T])(nil).clone(false)
+			copy(n.children, nodes[i:])
+			parents = append(parents, n)
+		}
+		nodes = parents
+		shift += bits
+	}
+	return nodes[0], shift
+}
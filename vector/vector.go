@@ -0,0 +1,478 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/slices"
+)
+
+// ErrOutOfRange represents an index is out of range of a vector.
+var ErrOutOfRange = errors.New("out of range")
+
+// V is a persistent immutable vector. The zero value is an empty vector.
+// The last elements in the same leaf are kept in a tail buffer, and pushed into the trie when the leaf is full,
+// so that appending does not copy the path from root every time.
+type V[T any] struct {
+	_     struct{}
+	root  *node[T]
+	shift uint
+	start int
+	end   int
+	tail  []T
+}
+
+// Empty returns an empty vector.
+func Empty[T any]() V[T] {
+	return V[T]{}
+}
+
+// One returns a vector with given element.
+func One[T any](v T) V[T] {
+	return From(v)
+}
+
+// From returns a vector containing given elements.
+func From[T any](a ...T) V[T] {
+	return FromSlice(a)
+}
+
+// FromSlice returns a vector containing elements of given slice s. Changing s later does not affect the vector.
+func FromSlice[T any](s slices.S[T]) V[T] {
+	root, shift := build(s)
+	return V[T]{root: root, shift: shift, end: len(s)}
+}
+
+// Fill returns a vector with length n and filled with given element.
+func Fill[T any](n int, v T) V[T] {
+	return FromSlice(slices.Fill(n, v))
+}
+
+// Tabulate returns a vector containing values of given function op over a range of integer values starting from 0 to given n.
+func Tabulate[T any](n int, op funcs.Func[int, T]) V[T] {
+	return FromSlice(slices.Tabulate(n, op))
+}
+
+// capacity returns numbers of indexes the root can hold.
+func (v V[T]) capacity() int {
+	return 1 << (v.shift + bits)
+}
+
+// tailStart returns the index of the first element in tail. Elements before it are in the trie.
+func (v V[T]) tailStart() int {
+	return v.end - len(v.tail)
+}
+
+// trim descends the root while all elements in the trie are in one of its children, so that unused levels are dropped.
+func (v V[T]) trim() V[T] {
+	if v.start >= v.end {
+		return Empty[T]()
+	}
+
+	last := v.tailStart()
+	if last <= v.start {
+		offset := v.start &^ mask
+		v.root, v.shift = nil, 0
+		v.start -= offset
+		v.end -= offset
+		return v
+	}
+
+	for v.shift > 0 && v.start>>v.shift == (last-1)>>v.shift {
+		idx := v.start >> v.shift
+		offset := idx << v.shift
+		v.root = v.root.children[idx]
+		v.shift -= bits
+		v.start -= offset
+		v.end -= offset
+		last -= offset
+	}
+	return v
+}
+
+// check panics with ErrOutOfRange if given index i is out of range.
+func (v V[T]) check(i int) {
+	if i < 0 || i >= v.Len() {
+		panic(fmt.Errorf(`%w: %d with length %d`, ErrOutOfRange, i, v.Len()))
+	}
+}
+
+// Len returns numbers of elements in v.
+func (v V[T]) Len() int {
+	return v.end - v.start
+}
+
+// IsEmpty returns true if v has no element.
+func (v V[T]) IsEmpty() bool {
+	return v.Len() <= 0
+}
+
+// Get returns the element at given index i. It panics with ErrOutOfRange if i is out of range.
+func (v V[T]) Get(i int) T {
+	v.check(i)
+	i += v.start
+	if t := v.tailStart(); i >= t {
+		return v.tail[i-t]
+	}
+	return v.root.leaf(v.shift, i).values[i&mask]
+}
+
+// Lift returns Some with the element at given index i, or returns None if i is out of range.
+func (v V[T]) Lift(i int) gs.Option[T] {
+	if i < 0 || i >= v.Len() {
+		return gs.None[T]()
+	}
+	return gs.Some(v.Get(i))
+}
+
+// Updated returns a new vector with the element at given index i replaced by x. It panics with ErrOutOfRange if i is out of range.
+func (v V[T]) Updated(i int, x T) V[T] {
+	v.check(i)
+	i += v.start
+	if t := v.tailStart(); i >= t {
+		tail := make([]T, len(v.tail))
+		copy(tail, v.tail)
+		tail[i-t] = x
+		v.tail = tail
+		return v
+	}
+	v.root = v.root.set(v.shift, i, x)
+	return v
+}
+
+// Appended returns a new vector with given x appended.
+func (v V[T]) Appended(x T) V[T] {
+	if v.end&mask == 0 && len(v.tail) > 0 {
+		v = v.push()
+	}
+
+	tail := make([]T, len(v.tail)+1)
+	copy(tail, v.tail)
+	tail[len(v.tail)] = x
+	v.tail = tail
+	v.end++
+	return v
+}
+
+// push pushes the tail into the trie.
+func (v V[T]) push() V[T] {
+	t := v.tailStart()
+	for t >= v.capacity() {
+		root := (*node[T])(nil).clone(false)
+		root.children[0] = v.root
+		v.root = root
+		v.shift += bits
+	}
+
+	v.root = v.root.push(v.shift, t, v.tail)
+	v.tail = nil
+	return v
+}
+
+// AppendedAll returns a new vector with given elements appended.
+func (v V[T]) AppendedAll(a ...T) V[T] {
+	for i := range a {
+		v = v.Appended(a[i])
+	}
+	return v
+}
+
+// Prepended returns a new vector with given x prepended.
+func (v V[T]) Prepended(x T) V[T] {
+	if v.start <= 0 {
+		offset := v.capacity()
+		root := (*node[T])(nil).clone(false)
+		root.children[1] = v.root
+		v.root = root
+		v.shift += bits
+		v.start += offset
+		v.end += offset
+	}
+
+	v.start--
+	v.root = v.root.set(v.shift, v.start, x)
+	return v
+}
+
+// Slice returns a new slice containing all elements of v.
+func (v V[T]) Slice() slices.S[T] {
+	ret := make(slices.S[T], 0, v.Len())
+	v.each(func(_ int, x T) bool {
+		ret = append(ret, x)
+		return true
+	})
+	return ret
+}
+
+// each applies given function op to elements of v in order until op returns false.
+func (v V[T]) each(op func(int, T) bool) bool {
+	t := v.tailStart()
+	for i := v.start; i < t; {
+		leaf := v.root.leaf(v.shift, i)
+		for j := i & mask; j < width && i < t; i, j = i+1, j+1 {
+			if !op(i-v.start, leaf.values[j]) {
+				return false
+			}
+		}
+	}
+
+	for i := range v.tail {
+		if !op(t+i-v.start, v.tail[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Range returns a new vector containing elements from index from until index until. Indexes are clamped to range of v.
+func (v V[T]) Range(from, until int) V[T] {
+	from = funcs.Max(0, funcs.Min(from, v.Len()))
+	until = funcs.Max(from, funcs.Min(until, v.Len()))
+	t := v.tailStart()
+	v.end = v.start + until
+	v.start += from
+
+	// keep elements of tail in the new range.
+	hi := funcs.Max(v.end, t) - t
+	v.tail = v.tail[funcs.Min(funcs.Max(v.start, t)-t, hi):hi]
+	return v.trim()
+}
+
+// SplitAt splits v into two vectors at given index n. It counts from the end if n is negative.
+func (v V[T]) SplitAt(n int) (V[T], V[T]) {
+	if n < 0 {
+		n += v.Len()
+	}
+	return v.Range(0, n), v.Range(n, v.Len())
+}
+
+// Take returns a new vector with first n elements if n is larger then 0, or returns last -n elements of v.
+func (v V[T]) Take(n int) V[T] {
+	a, b := v.SplitAt(n)
+	return funcs.Cond(n >= 0, a, b)
+}
+
+// Drop returns a new vector without first n elements if n is larger than 0, or returns first -n elements of v.
+func (v V[T]) Drop(n int) V[T] {
+	a, b := v.SplitAt(n)
+	return funcs.Cond(n >= 0, b, a)
+}
+
+// TakeWhile returns the longest prefix of v whose elements satisfy given function p.
+func (v V[T]) TakeWhile(p funcs.Predict[T]) V[T] {
+	return v.Take(v.prefix(p))
+}
+
+// DropWhile returns v without the longest prefix whose elements satisfy given function p.
+func (v V[T]) DropWhile(p funcs.Predict[T]) V[T] {
+	return v.Drop(v.prefix(p))
+}
+
+// prefix returns length of the longest prefix of v whose elements satisfy given function p.
+func (v V[T]) prefix(p funcs.Predict[T]) int {
+	ret := v.IndexWhere(func(x T) bool { return !p(x) })
+	return funcs.Cond(ret < 0, v.Len(), ret)
+}
+
+// Head returns Some with the first element, or returns None if v is empty.
+func (v V[T]) Head() gs.Option[T] {
+	return v.Lift(0)
+}
+
+// Last returns Some with the last element, or returns None if v is empty.
+func (v V[T]) Last() gs.Option[T] {
+	return v.Lift(v.Len() - 1)
+}
+
+// Tail returns v without the first element.
+func (v V[T]) Tail() V[T] {
+	return v.Drop(1)
+}
+
+// Heads returns v without the last element.
+func (v V[T]) Heads() V[T] {
+	return v.Range(0, v.Len()-1)
+}
+
+// IndexWhere returns index of the first element satisfying given function p, or -1 if not found.
+func (v V[T]) IndexWhere(p funcs.Predict[T]) int {
+	ret := -1
+	v.each(func(i int, x T) bool {
+		if p(x) {
+			ret = i
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// LastIndexWhere returns index of the last element satisfying given function p, or -1 if not found.
+func (v V[T]) LastIndexWhere(p funcs.Predict[T]) int {
+	for i := v.Len() - 1; i >= 0; i-- {
+		if p(v.Get(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Forall returns true if v is empty, or all elements satisfy given function p.
+func (v V[T]) Forall(p func(int, T) bool) bool {
+	return v.each(p)
+}
+
+// Exists returns true if at least one element satisfies given function p.
+func (v V[T]) Exists(p func(int, T) bool) bool {
+	return !v.each(func(i int, x T) bool { return !p(i, x) })
+}
+
+// Foreach applies given function op to all elements.
+func (v V[T]) Foreach(op func(int, T)) {
+	v.each(func(i int, x T) bool {
+		op(i, x)
+		return true
+	})
+}
+
+// Count returns numbers of elements satisfying given function p.
+func (v V[T]) Count(p funcs.Predict[T]) int {
+	return Fold(v, 0, func(z int, x T) int {
+		return funcs.Cond(p(x), z+1, z)
+	})
+}
+
+// Find returns Some with the first element satisfying given function p.
+func (v V[T]) Find(p funcs.Predict[T]) gs.Option[T] {
+	return v.Lift(v.IndexWhere(p))
+}
+
+// FindLast returns Some with the last element satisfying given function p.
+func (v V[T]) FindLast(p funcs.Predict[T]) gs.Option[T] {
+	return v.Lift(v.LastIndexWhere(p))
+}
+
+// Filter returns a new vector with all elements satisfying given function p.
+func (v V[T]) Filter(p funcs.Predict[T]) V[T] {
+	return FromSlice(v.Slice().Filter(p))
+}
+
+// FilterNot returns a new vector with all elements not satisfying given function p.
+func (v V[T]) FilterNot(p funcs.Predict[T]) V[T] {
+	return FromSlice(v.Slice().FilterNot(p))
+}
+
+// Partition returns two vectors. The first one contains all elements not satisfying given function p,
+// and the second one contains all elements satisfying p.
+func (v V[T]) Partition(p funcs.Predict[T]) (V[T], V[T]) {
+	a, b := v.Slice().Partition(p)
+	return FromSlice(a), FromSlice(b)
+}
+
+// ReduceLeft returns Some with the result of applying given function op to all elements from left to right, or returns None if v is empty.
+func (v V[T]) ReduceLeft(op func(T, T) T) gs.Option[T] {
+	return v.Slice().ReduceLeft(op)
+}
+
+// ReduceRight returns Some with the result of applying given function op to all elements from right to left, or returns None if v is empty.
+func (v V[T]) ReduceRight(op func(T, T) T) gs.Option[T] {
+	return v.Slice().ReduceRight(op)
+}
+
+// Reduce is same as ReduceLeft.
+func (v V[T]) Reduce(op func(T, T) T) gs.Option[T] {
+	return v.ReduceLeft(op)
+}
+
+// Max returns Some with the maximum element according to given cmp, or returns None if v is empty.
+func (v V[T]) Max(cmp funcs.Ordering[T, T]) gs.Option[T] {
+	return v.Slice().Max(cmp)
+}
+
+// Min returns Some with the minimum element according to given cmp, or returns None if v is empty.
+func (v V[T]) Min(cmp funcs.Ordering[T, T]) gs.Option[T] {
+	return v.Slice().Min(cmp)
+}
+
+// Sort returns a new vector sorted with given cmp. The sort is stable.
+func (v V[T]) Sort(cmp funcs.Ordering[T, T]) V[T] {
+	return FromSlice(v.Slice().Sort(cmp))
+}
+
+// Reverse returns a new vector with elements in reverse order.
+func (v V[T]) Reverse() V[T] {
+	return FromSlice(v.Slice().ReverseSelf())
+}
+
+// Concat returns a new vector with elements of v followed by elements of a.
+func (v V[T]) Concat(a V[T]) V[T] {
+	return FromSlice(append(v.Slice(), a.Slice()...))
+}
+
+func (v V[T]) String() string {
+	return fmt.Sprintf(`Vector(%s)`, strings.Join(Map(v, func(x T) string { return fmt.Sprint(x) }).Slice(), ", "))
+}
+
+// -----------------------------------------------------------------------------
+
+// TODO: refactor following functions to methods when go 1.19 releases.
+
+// FoldLeft applies given function op to a start value z and all elements of v from left to right.
+func FoldLeft[T, U any](v V[T], z U, op func(U, T) U) U {
+	v.Foreach(func(_ int, x T) {
+		z = op(z, x)
+	})
+	return z
+}
+
+// FoldRight applies given function op to all elements of v and a start value z from right to left.
+func FoldRight[T, U any](v V[T], z U, op func(T, U) U) U {
+	for i := v.Len() - 1; i >= 0; i-- {
+		z = op(v.Get(i), z)
+	}
+	return z
+}
+
+// Fold is same as FoldLeft.
+func Fold[T, U any](v V[T], z U, op func(U, T) U) U {
+	return FoldLeft(v, z, op)
+}
+
+// Map returns a new vector by applying given function op to all elements of v.
+func Map[T, U any](v V[T], op funcs.Func[T, U]) V[U] {
+	return FromSlice(slices.Map(v.Slice(), op))
+}
+
+// FlatMap returns a new vector by applying given function op to all elements of v and concatenating the results.
+func FlatMap[T, U any](v V[T], op funcs.Func[T, V[U]]) V[U] {
+	return FromSlice(Fold(v, slices.Empty[U](), func(z slices.S[U], x T) slices.S[U] {
+		return append(z, op(x).Slice()...)
+	}))
+}
+
+// Collect returns a new vector containing results of applying given partial function p on which it is defined.
+func Collect[T, U any](v V[T], p funcs.Partial[T, U]) V[U] {
+	return FromSlice(slices.Collect(v.Slice(), p))
+}
+
+// GroupBy partitions v into a map of vectors according to given discriminator function key.
+func GroupBy[T any, K comparable](v V[T], key funcs.Func[T, K]) map[K]V[T] {
+	ret := make(map[K]V[T])
+	for k, s := range slices.GroupBy(v.Slice(), key) {
+		ret[k] = FromSlice(s)
+	}
+	return ret
+}
+
+// Zip returns a new vector pairing elements of a and b at the same index. The result is truncated to the length of the shorter one.
+func Zip[A, B any](a V[A], b V[B]) V[gs.Tuple2[A, B]] {
+	return FromSlice(slices.Zip(a.Slice(), b.Slice()))
+}
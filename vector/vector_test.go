@@ -0,0 +1,308 @@
+// Copyright © 2022 Kigi Chang <kigi.chang@gmail.com>
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package vector_test
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/quick"
+
+	"github.com/dairaga/gs"
+	"github.com/dairaga/gs/funcs"
+	"github.com/dairaga/gs/slices"
+	"github.com/dairaga/gs/vector"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	even = func(v int) bool { return (v & 0x01) == 0 }
+)
+
+func TestFrom(t *testing.T) {
+	assert.True(t, vector.Empty[int]().IsEmpty())
+	assert.Equal(t, slices.Empty[int](), vector.Empty[int]().Slice())
+	assert.Equal(t, slices.From(1), vector.One(1).Slice())
+	assert.Equal(t, slices.From(1, 2, 3), vector.From(1, 2, 3).Slice())
+	assert.Equal(t, slices.Fill(3, "a"), vector.Fill(3, "a").Slice())
+
+	for _, n := range slices.From(0, 1, 31, 32, 33, 1023, 1024, 1025, 40000) {
+		s := slices.Range(0, n, 1)
+		v := vector.FromSlice(s)
+		assert.Equal(t, n, v.Len())
+		assert.Equal(t, s, v.Slice())
+		assert.Equal(t, v.Slice(), vector.Tabulate(n, funcs.Self[int]).Slice())
+	}
+
+	s := slices.From(1, 2, 3)
+	v := vector.FromSlice(s)
+	s[0] = 100
+	assert.Equal(t, 1, v.Get(0))
+}
+
+func TestGet(t *testing.T) {
+	v := vector.Tabulate(100, funcs.Self[int])
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i, v.Get(i))
+	}
+
+	assert.Equal(t, gs.Some(5), v.Lift(5))
+	assert.True(t, v.Lift(100).IsEmpty())
+	assert.True(t, v.Lift(-1).IsEmpty())
+
+	assert.PanicsWithError(t, "out of range: 100 with length 100", func() { v.Get(100) })
+	defer func() {
+		assert.True(t, errors.Is(recover().(error), vector.ErrOutOfRange))
+	}()
+	v.Updated(-1, 0)
+}
+
+func TestPersistent(t *testing.T) {
+	v1 := vector.Tabulate(100, funcs.Self[int])
+	v2 := v1.Updated(50, -1)
+	v3 := v1.Appended(100)
+	v4 := v1.Prepended(-1)
+	v5 := v1.Drop(10).Take(10)
+
+	assert.Equal(t, slices.Range(0, 100, 1), v1.Slice())
+	assert.Equal(t, -1, v2.Get(50))
+	assert.Equal(t, 101, v3.Len())
+	assert.Equal(t, 101, v4.Len())
+	assert.Equal(t, 100, v3.Get(100))
+	assert.Equal(t, -1, v4.Get(0))
+	assert.Equal(t, 99, v4.Get(100))
+	assert.Equal(t, slices.Range(10, 20, 1), v5.Slice())
+
+	v6 := v5.Appended(-2)
+	assert.Equal(t, 20, v1.Get(20))
+	assert.Equal(t, -2, v6.Get(10))
+}
+
+func TestAppendedPrepended(t *testing.T) {
+	v := vector.Empty[int]()
+	for i := 0; i < 2000; i++ {
+		v = v.Appended(i)
+	}
+	assert.Equal(t, slices.Range(0, 2000, 1), v.Slice())
+
+	v = vector.Empty[int]()
+	for i := 1999; i >= 0; i-- {
+		v = v.Prepended(i)
+	}
+	assert.Equal(t, slices.Range(0, 2000, 1), v.Slice())
+
+	v = vector.Empty[int]()
+	for i := 0; i < 1000; i++ {
+		v = v.Prepended(-i - 1).Appended(i)
+	}
+	assert.Equal(t, slices.Range(-1000, 1000, 1), v.Slice())
+	assert.Equal(t, slices.From(1, 2, 3, 4), vector.One(1).AppendedAll(2, 3, 4).Slice())
+}
+
+func TestTail(t *testing.T) {
+	v := vector.Tabulate(40, funcs.Self[int])
+	a := v.Appended(-1)
+	b := v.Appended(-2)
+	assert.Equal(t, -1, a.Get(40))
+	assert.Equal(t, -2, b.Get(40))
+	assert.Equal(t, 40, v.Len())
+
+	a = a.AppendedAll(slices.Range(41, 100, 1)...)
+	c := a.Updated(90, -3)
+	assert.Equal(t, 90, a.Get(90))
+	assert.Equal(t, -3, c.Get(90))
+	assert.Equal(t, -2, b.Get(40))
+
+	r := rand.New(rand.NewSource(1))
+	s := slices.Empty[int]()
+	v = vector.Empty[int]()
+	for i := 0; i < 5000; i++ {
+		switch r.Intn(10) {
+		case 0:
+			s = append(slices.From(i), s...)
+			v = v.Prepended(i)
+		case 1:
+			if len(s) > 0 {
+				from := r.Intn(len(s))
+				until := from + r.Intn(len(s)-from+1)
+				s = s[from:until].Clone()
+				v = v.Range(from, until)
+			}
+		case 2:
+			if len(s) > 0 {
+				j := r.Intn(len(s))
+				s[j] = -i
+				v = v.Updated(j, -i)
+			}
+		default:
+			s = append(s, i)
+			v = v.Appended(i)
+		}
+
+		if !assert.Equal(t, s, v.Slice()) {
+			return
+		}
+		if len(s) > 0 {
+			assert.Equal(t, s[len(s)-1], v.Get(len(s)-1))
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	v := vector.Tabulate(2000, funcs.Self[int])
+	s := v.Slice()
+
+	assert.Equal(t, s[10:1500], v.Range(10, 1500).Slice())
+	assert.Equal(t, s[:0], v.Range(10, 5).Slice())
+	assert.Equal(t, s, v.Range(-10, 5000).Slice())
+	assert.Equal(t, s.Take(100), v.Take(100).Slice())
+	assert.Equal(t, s.Take(-100), v.Take(-100).Slice())
+	assert.Equal(t, s.Drop(100), v.Drop(100).Slice())
+	assert.Equal(t, s.Drop(-100), v.Drop(-100).Slice())
+	assert.Equal(t, s.Tail(), v.Tail().Slice())
+	assert.Equal(t, s.Heads(), v.Heads().Slice())
+
+	a, b := v.SplitAt(1024)
+	assert.Equal(t, s[:1024], a.Slice())
+	assert.Equal(t, s[1024:], b.Slice())
+
+	r := v.Range(1000, 1040).Prepended(-1).Appended(-2).Updated(1, 0)
+	assert.Equal(t, 42, r.Len())
+	assert.Equal(t, -1, r.Get(0))
+	assert.Equal(t, 0, r.Get(1))
+	assert.Equal(t, -2, r.Get(41))
+	assert.Equal(t, 1000, v.Get(1000))
+	assert.True(t, v.Range(100, 100).IsEmpty())
+	assert.Equal(t, slices.From(5), v.Range(5, 6).Appended(6).Range(0, 1).Slice())
+}
+
+func TestQuick(t *testing.T) {
+	ops := func(s []int, n, m int, x int) bool {
+		v := vector.FromSlice(s)
+		sv := slices.S[int](s).Clone()
+
+		if !slices.Equal(sv, v.Slice()) {
+			return false
+		}
+
+		if len(s) > 0 {
+			i := (n%len(s) + len(s)) % len(s)
+			u := v.Updated(i, x)
+			sv2 := sv.Clone()
+			sv2[i] = x
+			if !slices.Equal(sv2, u.Slice()) || !slices.Equal(sv, v.Slice()) {
+				return false
+			}
+		}
+
+		n, m = n%(len(s)+10), m%(len(s)+10)
+		return slices.Equal(sv.Take(n), v.Take(n).Slice()) &&
+			slices.Equal(sv.Drop(m), v.Drop(m).Slice()) &&
+			slices.Equal(append(sv.Clone(), x), v.Appended(x).Slice()) &&
+			slices.Equal(append(slices.From(x), sv...), v.Prepended(x).Slice()) &&
+			slices.Equal(sv.Drop(m).Take(n), v.Drop(m).Take(n).Slice())
+	}
+	assert.NoError(t, quick.Check(ops, &quick.Config{MaxCount: 300}))
+}
+
+func TestMethods(t *testing.T) {
+	v := vector.From(1, 2, 3, 4, 5)
+
+	assert.Equal(t, gs.Some(1), v.Head())
+	assert.Equal(t, gs.Some(5), v.Last())
+	assert.True(t, vector.Empty[int]().Head().IsEmpty())
+
+	assert.Equal(t, slices.From(2, 4), v.Filter(even).Slice())
+	assert.Equal(t, slices.From(1, 3, 5), v.FilterNot(even).Slice())
+	a, b := v.Partition(even)
+	assert.Equal(t, slices.From(1, 3, 5), a.Slice())
+	assert.Equal(t, slices.From(2, 4), b.Slice())
+
+	assert.Equal(t, gs.Some(2), v.Find(even))
+	assert.Equal(t, gs.Some(4), v.FindLast(even))
+	assert.Equal(t, 1, v.IndexWhere(even))
+	assert.Equal(t, 3, v.LastIndexWhere(even))
+	assert.Equal(t, -1, v.IndexWhere(func(x int) bool { return x > 5 }))
+	assert.Equal(t, 2, v.Count(even))
+
+	assert.True(t, v.Forall(func(_ int, x int) bool { return x > 0 }))
+	assert.False(t, v.Forall(func(_ int, x int) bool { return x > 1 }))
+	assert.True(t, v.Exists(func(i int, x int) bool { return i == 4 && x == 5 }))
+	assert.False(t, v.Exists(func(_ int, x int) bool { return x > 5 }))
+
+	sum := 0
+	v.Foreach(func(i int, x int) { sum += i * x })
+	assert.Equal(t, 40, sum)
+
+	lt3 := func(x int) bool { return x < 3 }
+	assert.Equal(t, slices.From(1, 2), v.TakeWhile(lt3).Slice())
+	assert.Equal(t, slices.From(3, 4, 5), v.DropWhile(lt3).Slice())
+	assert.Equal(t, v.Slice(), v.TakeWhile(func(x int) bool { return x > 0 }).Slice())
+	assert.True(t, v.DropWhile(func(x int) bool { return x > 0 }).IsEmpty())
+
+	minus := func(a, b int) int { return a - b }
+	assert.Equal(t, gs.Some(-13), v.ReduceLeft(minus))
+	assert.Equal(t, gs.Some(3), v.ReduceRight(minus))
+	assert.Equal(t, gs.Some(15), v.Reduce(func(a, b int) int { return a + b }))
+	assert.Equal(t, gs.Some(5), v.Max(funcs.Order[int]))
+	assert.Equal(t, gs.Some(1), v.Min(funcs.Order[int]))
+
+	r := v.Reverse()
+	assert.Equal(t, slices.From(5, 4, 3, 2, 1), r.Slice())
+	assert.Equal(t, v.Slice(), r.Sort(funcs.Order[int]).Slice())
+	assert.Equal(t, slices.From(5, 4, 3, 2, 1), r.Slice())
+	assert.Equal(t, slices.From(1, 2, 3, 4, 5, 5, 4, 3, 2, 1), v.Concat(r).Slice())
+
+	assert.Equal(t, "Vector(1, 2, 3, 4, 5)", v.String())
+	assert.Equal(t, "Vector()", vector.Empty[int]().String())
+}
+
+func TestFunctions(t *testing.T) {
+	v := vector.From(1, 2, 3, 4, 5)
+
+	assert.Equal(t, "12345", vector.FoldLeft(v, "", func(z string, x int) string { return z + strconv.Itoa(x) }))
+	assert.Equal(t, "54321", vector.FoldRight(v, "", func(x int, z string) string { return z + strconv.Itoa(x) }))
+	assert.Equal(t, 15, vector.Fold(v, 0, func(z, x int) int { return z + x }))
+
+	assert.Equal(t, slices.From("1", "2", "3", "4", "5"), vector.Map(v, strconv.Itoa).Slice())
+	assert.Equal(t, slices.From(1, 1, 2, 2), vector.FlatMap(v.Take(2), func(x int) vector.V[int] { return vector.Fill(2, x) }).Slice())
+	assert.Equal(t, slices.From(4, 8), vector.Collect(v, func(x int) (int, bool) { return x * 2, even(x) }).Slice())
+
+	groups := vector.GroupBy(v, even)
+	assert.Equal(t, slices.From(2, 4), groups[true].Slice())
+	assert.Equal(t, slices.From(1, 3, 5), groups[false].Slice())
+
+	assert.Equal(t, slices.From(gs.T2(1, "a"), gs.T2(2, "b")), vector.Zip(v, vector.From("a", "b")).Slice())
+}
+
+func TestConcurrent(t *testing.T) {
+	v := vector.Tabulate(1000, funcs.Self[int])
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			u := v
+			for j := 0; j < 1000; j++ {
+				u = u.Updated(j, j*i).Appended(i)
+			}
+			assert.Equal(t, 2000, u.Len())
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, slices.Range(0, 1000, 1), v.Slice())
+}
+
+func BenchmarkAppended(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := vector.Empty[int]()
+		for j := 0; j < 1000; j++ {
+			v = v.Appended(j)
+		}
+	}
+}